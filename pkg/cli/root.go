@@ -0,0 +1,49 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+
+	"github.com/abcxyz/pkg/cli"
+)
+
+// rootCmd defines the subcommands available besides serving the plugin.
+var rootCmd = func() *cli.RootCommand {
+	return &cli.RootCommand{
+		Name: "jvs-plugin-github",
+		Commands: map[string]cli.CommandFactory{
+			"server": func() cli.Command {
+				return &ServerCommand{}
+			},
+			"verify-snapshot": func() cli.Command {
+				return &VerifySnapshotCommand{}
+			},
+		},
+	}
+}
+
+// Run executes the command for the given arguments. JVS starts plugins without
+// any arguments, so unless the first argument names a subcommand the plugin
+// server is run.
+func Run(ctx context.Context, args []string) error {
+	root := rootCmd()
+	if len(args) > 0 {
+		if _, ok := root.Commands[args[0]]; ok {
+			return root.Run(ctx, args) //nolint:wrapcheck // Want passthrough
+		}
+	}
+	return new(ServerCommand).Run(ctx, args)
+}
@@ -17,7 +17,13 @@ package cli
 
 import (
 	"context"
+	"expvar"
 	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/google/go-github/v55/github"
 	goplugin "github.com/hashicorp/go-plugin"
@@ -27,6 +33,20 @@ import (
 	"github.com/abcxyz/pkg/cli"
 	"github.com/abcxyz/pkg/githubauth"
 	"github.com/abcxyz/pkg/logging"
+	"github.com/abcxyz/pkg/serving"
+)
+
+const (
+	// githubAPITimeout bounds the duration of a GitHub API call.
+	githubAPITimeout = 30 * time.Second
+
+	// githubTokenTimeout bounds the duration of a call of the GitHub App token
+	// exchange.
+	githubTokenTimeout = 10 * time.Second
+
+	// shutdownTimeout bounds how long in-flight write-backs are waited for
+	// when the plugin stops.
+	shutdownTimeout = 30 * time.Second
 )
 
 type ServerCommand struct {
@@ -59,6 +79,36 @@ func (c *ServerCommand) Run(ctx context.Context, args []string) error {
 		return fmt.Errorf("failed to instantiate github plugin: %w", err)
 	}
 
+	if c.cfg.GitHubSuggestPort != "" {
+		listener, err := net.Listen("tcp", net.JoinHostPort(c.cfg.GitHubSuggestHost, c.cfg.GitHubSuggestPort))
+		if err != nil {
+			return fmt.Errorf("failed to listen on suggestion address: %w", err)
+		}
+		server, err := serving.NewFromListener(listener)
+		if err != nil {
+			return fmt.Errorf("failed to create suggestion server: %w", err)
+		}
+		// The plugin server blocks until JVS stops the plugin, so serve the
+		// suggestions in the background.
+		go func() {
+			if err := server.StartHTTPHandler(ctx, plugin.NewSuggestHandler(p, c.cfg.GitHubSuggestToken, c.cfg.GitHubSuggestRateLimit, c.cfg.GitHubSuggestGlobalRateLimit)); err != nil {
+				logging.FromContext(ctx).ErrorContext(ctx, "suggestion server failed", "error", err)
+			}
+		}()
+	}
+
+	if c.cfg.GitHubMetricsPort != "" {
+		server, err := serving.New(c.cfg.GitHubMetricsPort)
+		if err != nil {
+			return fmt.Errorf("failed to create metrics server: %w", err)
+		}
+		go func() {
+			if err := server.StartHTTPHandler(ctx, expvar.Handler()); err != nil {
+				logging.FromContext(ctx).ErrorContext(ctx, "metrics server failed", "error", err)
+			}
+		}()
+	}
+
 	goplugin.Serve(&goplugin.ServeConfig{
 		HandshakeConfig: jvspb.Handshake,
 		Plugins: map[string]goplugin.Plugin{
@@ -69,6 +119,12 @@ func (c *ServerCommand) Run(ctx context.Context, args []string) error {
 		GRPCServer: goplugin.DefaultGRPCServer,
 	})
 
+	// The context may already be canceled when JVS stops the plugin.
+	closeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+	defer cancel()
+	if err := p.Close(closeCtx); err != nil {
+		return fmt.Errorf("failed to close github plugin: %w", err)
+	}
 	return nil
 }
 
@@ -87,28 +143,63 @@ func (c *ServerCommand) RunUnstarted(ctx context.Context, args []string) (*plugi
 	if err := c.cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
+	if err := c.cfg.LoadCategories(c.LookupEnv); err != nil {
+		return nil, fmt.Errorf("invalid categories: %w", err)
+	}
 	logger.DebugContext(ctx, "loaded configuration",
 		"github_app_id", c.cfg.GitHubAppID,
-		"github_app_installation_id", c.cfg.GitHubAppInstallationID)
-
-	//  If a nil httpClient is provided, a new http.Client will be used.
-	ghClient := github.NewClient(nil)
+		"github_app_installation_id", c.cfg.GitHubAppInstallationID,
+		"github_plugin_category", c.cfg.GitHubPluginCategory)
 
-	signer, err := githubauth.NewPrivateKeySigner(c.cfg.GitHubAppPrivateKeyPEM)
+	ghClient, ghInstall, err := newGitHubClients(ctx, c.cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse private key: %w", err)
+		return nil, err
 	}
-	ghApp, err := githubauth.NewApp(c.cfg.GitHubAppID, signer,
-		githubauth.WithBaseURL(c.cfg.GitHubAPIBaseURL))
+
+	p, err := plugin.NewGitHubPlugin(ctx, ghClient, ghInstall, c.cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create github app: %w", err)
+		return nil, fmt.Errorf("failed to create github plugin: %w", err)
+	}
+	return p, nil
+}
+
+// newGitHubClients creates the GitHub API client and the GitHub App
+// installation described by the given config. Their calls share a circuit
+// breaker.
+func newGitHubClients(ctx context.Context, cfg *plugin.PluginConfig) (*github.Client, *githubauth.AppInstallation, error) {
+	breaker := plugin.NewCircuitBreaker(cfg.GitHubCircuitBreakerThreshold, cfg.GitHubCircuitBreakerCooldown)
+	apiHTTPClient := &http.Client{
+		Timeout:   githubAPITimeout,
+		Transport: breaker.Transport(http.DefaultTransport),
+	}
+	tokenHTTPClient := &http.Client{
+		Timeout:   githubTokenTimeout,
+		Transport: plugin.TokenStatusTransport(breaker.Transport(http.DefaultTransport)),
+	}
+
+	ghClient := github.NewClient(apiHTTPClient)
+	if cfg.GitHubAPIBaseURL != plugin.DefaultGitHubAPIBaseURL {
+		u, err := url.Parse(strings.TrimSuffix(cfg.GitHubAPIBaseURL, "/") + "/")
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse github api base url: %w", err)
+		}
+		ghClient.BaseURL = u
 	}
 
-	ghInstall, err := ghApp.InstallationForID(ctx, c.cfg.GitHubAppInstallationID)
+	signer, err := githubauth.NewPrivateKeySigner(cfg.GitHubAppPrivateKeyPEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	ghApp, err := githubauth.NewApp(cfg.GitHubAppID, signer,
+		githubauth.WithBaseURL(cfg.GitHubAPIBaseURL),
+		githubauth.WithHTTPClient(tokenHTTPClient))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get github installation: %w", err)
+		return nil, nil, fmt.Errorf("failed to create github app: %w", err)
 	}
 
-	p := plugin.NewGitHubPlugin(ctx, ghClient, ghInstall, c.cfg)
-	return p, nil
+	ghInstall, err := ghApp.InstallationForID(ctx, cfg.GitHubAppInstallationID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get github installation: %w", err)
+	}
+	return ghClient, ghInstall, nil
 }
@@ -0,0 +1,182 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2/jwt"
+
+	"github.com/abcxyz/jvs-plugin-github/pkg/plugin"
+	jvspb "github.com/abcxyz/jvs/apis/v0"
+	"github.com/abcxyz/pkg/cli"
+)
+
+var _ cli.Command = (*VerifySnapshotCommand)(nil)
+
+// VerifySnapshotCommand checks whether the issues used as justifications have
+// changed since the JVS token was issued.
+type VerifySnapshotCommand struct {
+	cli.BaseCommand
+
+	cfg *plugin.PluginConfig
+
+	flagToken      string
+	flagAnnotation map[string]string
+}
+
+func (c *VerifySnapshotCommand) Desc() string {
+	return `Verify issues have not changed since a token was issued`
+}
+
+func (c *VerifySnapshotCommand) Help() string {
+	return `
+Usage: {{ COMMAND }} [options]
+
+  Compare the issue snapshot hashes recorded in a JVS token's annotations
+  against the current content of the issues. The token signature is not
+  verified, use "jvsctl token validate" for that.
+
+  Verify all GitHub issue justifications in a token:
+
+      {{ COMMAND }} -token "eyJhbGciOi..."
+
+  Verify from annotations directly:
+
+      {{ COMMAND }} \
+        -annotation github_issue_url=https://github.com/owner/repo/issues/1 \
+        -annotation github_issue_snapshot_hash=sha256:...
+`
+}
+
+func (c *VerifySnapshotCommand) Flags() *cli.FlagSet {
+	c.cfg = &plugin.PluginConfig{}
+	set := c.NewFlagSet()
+
+	f := set.NewSection("COMMAND OPTIONS")
+
+	f.StringVar(&cli.StringVar{
+		Name:    "token",
+		Target:  &c.flagToken,
+		Example: "eyJhbGciOi...",
+		Usage: `The JVS token whose justification annotations are verified. Set ` +
+			`the value to "-" to read from stdin.`,
+	})
+
+	f.StringMapVar(&cli.StringMapVar{
+		Name:    "annotation",
+		Target:  &c.flagAnnotation,
+		Example: "github_issue_url=https://github.com/owner/repo/issues/1",
+		Usage: `Justification annotation to verify, as key=value. Used instead ` +
+			`of -token.`,
+	})
+
+	return c.cfg.ToFlags(set)
+}
+
+func (c *VerifySnapshotCommand) Run(ctx context.Context, args []string) error {
+	f := c.Flags()
+	if err := f.Parse(args); err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+	args = f.Args()
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected arguments: %q", args)
+	}
+
+	if (c.flagToken == "") == (len(c.flagAnnotation) == 0) {
+		return fmt.Errorf("exactly one of -token or -annotation must be specified")
+	}
+	if err := c.cfg.ValidateGitHubApp(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	annotations, err := c.annotations()
+	if err != nil {
+		return err
+	}
+
+	ghClient, ghInstall, err := newGitHubClients(ctx, c.cfg)
+	if err != nil {
+		return err
+	}
+	v := plugin.NewValidator(ghClient, ghInstall, &c.cfg.Policy)
+	v.SetUsedLabel(c.cfg.GitHubUsedLabel)
+
+	var verified int
+	var merr error
+	for _, a := range annotations {
+		results, err := v.VerifyIssueSnapshot(ctx, a)
+		if err != nil {
+			if errors.Is(err, plugin.ErrMissingSnapshot) {
+				continue
+			}
+			return fmt.Errorf("failed to verify issue snapshot: %w", err)
+		}
+
+		for _, result := range results {
+			verified++
+			if result.Changed() {
+				c.Outf("CHANGED   %s (recorded %s, current %s)", result.IssueURL, result.RecordedHash, result.CurrentHash)
+				merr = errors.Join(merr, fmt.Errorf("issue %s has changed since validation", result.IssueURL))
+				continue
+			}
+			c.Outf("UNCHANGED %s", result.IssueURL)
+		}
+	}
+
+	if verified == 0 {
+		return fmt.Errorf("no issue snapshots found to verify")
+	}
+	return merr
+}
+
+// annotations returns the justification annotations to verify, either from the
+// token or from the annotation flags.
+func (c *VerifySnapshotCommand) annotations() ([]map[string]string, error) {
+	if len(c.flagAnnotation) > 0 {
+		return []map[string]string{c.flagAnnotation}, nil
+	}
+
+	raw := c.flagToken
+	if raw == "-" {
+		b, err := io.ReadAll(io.LimitReader(c.Stdin(), 64*1024))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read token from stdin: %w", err)
+		}
+		raw = string(b)
+	}
+
+	// The signature is intentionally not verified here, this command only
+	// compares the recorded annotations against GitHub.
+	token, err := jwt.ParseInsecure([]byte(strings.TrimSpace(raw)), jvspb.WithTypedJustifications())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+	justifications, err := jvspb.GetJustifications(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get justifications from token: %w", err)
+	}
+
+	annotations := make([]map[string]string, 0, len(justifications))
+	for _, j := range justifications {
+		annotations = append(annotations, j.GetAnnotation())
+	}
+	return annotations, nil
+}
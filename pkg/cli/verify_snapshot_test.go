@@ -0,0 +1,177 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+
+	"github.com/abcxyz/jvs-plugin-github/pkg/plugin/keyutil"
+	jvspb "github.com/abcxyz/jvs/apis/v0"
+	"github.com/abcxyz/pkg/cli"
+	"github.com/abcxyz/pkg/logging"
+	"github.com/abcxyz/pkg/testutil"
+)
+
+const (
+	testIssueURL  = "https://github.com/test-owner/test-repo/issues/1"
+	testIssueURL2 = "https://github.com/test-owner/test-repo/issues/2"
+	// testIssueSnapshotHash is the snapshot hash of the issue served by the
+	// fake GitHub server below.
+	testIssueSnapshotHash = "sha256:6ec91ac04de3e572b343b0a3390c024a1758ae8e8317128c0384f6f517edc133"
+)
+
+func TestVerifySnapshotCommand(t *testing.T) {
+	t.Parallel()
+
+	testRSAPrivateKeyString, testRSAPrivateKey := keyutil.TestGenerateRSAPrivateKey(t)
+
+	ctx := logging.WithLogger(t.Context(), logging.TestLogger(t))
+
+	fakeGitHub := func() *httptest.Server {
+		mux := http.NewServeMux()
+		mux.Handle("GET /app/installations/123", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"access_tokens_url": "http://%s/app/installations/123/access_tokens"}`, r.Host)
+		}))
+		mux.Handle("POST /app/installations/123/access_tokens", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(201)
+			fmt.Fprintf(w, `{"token": "this-is-the-token-from-github"}`)
+		}))
+		mux.Handle("GET /repos/test-owner/test-repo/issues/{number}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"state": "open"}`)
+		}))
+		return httptest.NewServer(mux)
+	}()
+	t.Cleanup(func() {
+		fakeGitHub.Close()
+	})
+
+	env := map[string]string{
+		"GITHUB_APP_ID":              "my-app",
+		"GITHUB_APP_INSTALLATION_ID": "123",
+		"GITHUB_APP_PRIVATE_KEY_PEM": testRSAPrivateKeyString,
+		"GITHUB_API_BASE_URL":        fakeGitHub.URL,
+	}
+
+	testToken := func(tb testing.TB, annotation map[string]string) string {
+		tb.Helper()
+
+		token := jwt.New()
+		if err := jvspb.SetJustifications(token, []*jvspb.Justification{
+			{Category: "explanation", Value: "unrelated"},
+			{Category: "github", Value: testIssueURL, Annotation: annotation},
+		}); err != nil {
+			tb.Fatal(err)
+		}
+		b, err := jwt.Sign(token, jwt.WithKey(jwa.RS256, testRSAPrivateKey))
+		if err != nil {
+			tb.Fatal(err)
+		}
+		return string(b)
+	}
+
+	cases := []struct {
+		name    string
+		args    []string
+		stdin   string
+		wantOut string
+		wantErr string
+	}{
+		{
+			name: "token_unchanged",
+			args: []string{"-token", testToken(t, map[string]string{
+				"github_issue_url":           testIssueURL,
+				"github_issue_snapshot_hash": testIssueSnapshotHash,
+			})},
+			wantOut: "UNCHANGED " + testIssueURL,
+		},
+		{
+			name: "token_stdin_changed",
+			args: []string{"-token", "-"},
+			stdin: testToken(t, map[string]string{
+				"github_issue_url":           testIssueURL,
+				"github_issue_snapshot_hash": "sha256:old",
+			}),
+			wantOut: "CHANGED   " + testIssueURL,
+			wantErr: "has changed since validation",
+		},
+		{
+			name: "token_multiple_references_changed",
+			args: []string{"-token", testToken(t, map[string]string{
+				"github_refs":                      testIssueURL + "," + testIssueURL2,
+				"github_ref_0_url":                 testIssueURL,
+				"github_ref_0_issue_url":           testIssueURL,
+				"github_ref_0_issue_snapshot_hash": testIssueSnapshotHash,
+				"github_ref_1_url":                 testIssueURL2,
+				"github_ref_1_issue_url":           testIssueURL2,
+				"github_ref_1_issue_snapshot_hash": "sha256:old",
+			})},
+			wantOut: "UNCHANGED " + testIssueURL + "\nCHANGED   " + testIssueURL2,
+			wantErr: "issue " + testIssueURL2 + " has changed since validation",
+		},
+		{
+			name: "annotations_unchanged",
+			args: []string{
+				"-annotation", "github_issue_url=" + testIssueURL,
+				"-annotation", "github_issue_snapshot_hash=" + testIssueSnapshotHash,
+			},
+			wantOut: "UNCHANGED " + testIssueURL,
+		},
+		{
+			name:    "no_snapshot",
+			args:    []string{"-token", testToken(t, nil)},
+			wantErr: "no issue snapshots found",
+		},
+		{
+			name:    "missing_input",
+			wantErr: "exactly one of -token or -annotation must be specified",
+		},
+		{
+			name:    "invalid_token",
+			args:    []string{"-token", "not-a-token"},
+			wantErr: "failed to parse token",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, done := context.WithCancel(ctx)
+			defer done()
+
+			var cmd VerifySnapshotCommand
+			cmd.SetLookupEnv(cli.MapLookuper(env))
+
+			stdin, stdout, _ := cmd.Pipe()
+			stdin.WriteString(tc.stdin)
+
+			err := cmd.Run(ctx, tc.args)
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Fatal(diff)
+			}
+			if got := strings.TrimSpace(stdout.String()); !strings.Contains(got, tc.wantOut) {
+				t.Errorf("expected stdout %q to contain %q", got, tc.wantOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+const (
+	// defaultApprovalReaction and defaultApprovalComment are the reaction and
+	// comment command approving an issue by default.
+	defaultApprovalReaction = "+1"
+	defaultApprovalComment  = "/approve"
+
+	// approvalPageSize and maxApprovalPages bound the number of comments and
+	// reactions scanned for approvals.
+	approvalPageSize = 100
+	maxApprovalPages = 10
+)
+
+// approvalReactions are the reactions GitHub supports.
+var approvalReactions = []string{"+1", "-1", "laugh", "confused", "heart", "hooray", "rocket", "eyes"}
+
+// issueReaction is a reaction on an issue. The client library doesn't include
+// the reaction creation time.
+type issueReaction struct {
+	User      *github.User `json:"user"`
+	Content   string       `json:"content"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// approval is a reaction or comment approving an issue.
+type approval struct {
+	Login string
+	Time  time.Time
+}
+
+// validateApproval verifies the issue was approved by a member of one of the
+// approval teams other than the issue author, by reaction or comment, and
+// records the earliest such approval. JVS doesn't pass the requester to
+// plugins, so approvals by the requester are NOT excluded: a team member can
+// approve an issue authored by someone else and cite it for their own access.
+func (v *Validator) validateApproval(ctx context.Context, c *github.Client, pi *pluginGitHubIssue, author string) error {
+	if author == "" {
+		return invalidf(ReasonApprovalMissing, "issue approval by %q cannot be verified without the issue author",
+			v.policy.ApprovalTeams)
+	}
+
+	candidates, err := v.listApprovals(ctx, c, pi)
+	if err != nil {
+		return err
+	}
+	slices.SortStableFunc(candidates, func(a, b *approval) int { return a.Time.Compare(b.Time) })
+
+	checked := make(map[string]struct{}, len(candidates))
+	for _, a := range candidates {
+		login := strings.ToLower(a.Login)
+		if strings.EqualFold(a.Login, author) {
+			continue
+		}
+		if _, ok := checked[login]; ok {
+			continue
+		}
+
+		team, err := v.teams.MemberOfAny(ctx, v.policy.ApprovalTeams, a.Login)
+		if err != nil {
+			return fmt.Errorf("failed to check approver team membership: %w", err)
+		}
+		checked[login] = struct{}{}
+		if team != "" {
+			pi.Approver = a.Login
+			pi.ApprovedAt = a.Time
+			return nil
+		}
+	}
+
+	return invalidf(ReasonApprovalMissing, "issue is not approved by a member of %q other than the issue author, react with %q or comment %q to approve",
+		v.policy.ApprovalTeams, v.approvalReaction(), v.approvalComment())
+}
+
+// listApprovals lists the approving reactions and comments of the issue.
+func (v *Validator) listApprovals(ctx context.Context, c *github.Client, pi *pluginGitHubIssue) ([]*approval, error) {
+	var approvals []*approval
+
+	reaction := v.approvalReaction()
+	for page := 1; page <= maxApprovalPages; page++ {
+		// The client library doesn't include the reaction creation time.
+		req, err := c.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/issues/%d/reactions?per_page=%d&page=%d",
+			pi.Owner, pi.RepoName, pi.IssueNumber, approvalPageSize, page), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create issue reactions request: %w", err)
+		}
+		var reactions []*issueReaction
+		resp, err := c.Do(ctx, req, &reactions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list issue reactions: %w", err)
+		}
+		for _, r := range reactions {
+			if r.Content == reaction && r.User.GetLogin() != "" {
+				approvals = append(approvals, &approval{Login: r.User.GetLogin(), Time: r.CreatedAt})
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+	}
+
+	command := v.approvalComment()
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: approvalPageSize}}
+	for page := 1; page <= maxApprovalPages; page++ {
+		comments, resp, err := c.Issues.ListComments(ctx, pi.Owner, pi.RepoName, pi.IssueNumber, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list issue comments: %w", err)
+		}
+		for _, comment := range comments {
+			if isApprovalComment(comment.GetBody(), command) && comment.GetUser().GetLogin() != "" {
+				approvals = append(approvals, &approval{Login: comment.GetUser().GetLogin(), Time: comment.GetCreatedAt().Time})
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return approvals, nil
+}
+
+// isApprovalComment reports whether any line of the comment body is the
+// approval command.
+func isApprovalComment(body, command string) bool {
+	for _, line := range strings.Split(body, "\n") {
+		if strings.EqualFold(strings.TrimSpace(line), command) {
+			return true
+		}
+	}
+	return false
+}
+
+// approvalReaction returns the configured approval reaction or the default.
+func (v *Validator) approvalReaction() string {
+	if v.policy.ApprovalReaction != "" {
+		return v.policy.ApprovalReaction
+	}
+	return defaultApprovalReaction
+}
+
+// approvalComment returns the configured approval comment or the default.
+func (v *Validator) approvalComment() string {
+	if v.policy.ApprovalComment != "" {
+		return v.policy.ApprovalComment
+	}
+	return defaultApprovalComment
+}
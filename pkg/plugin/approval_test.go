@@ -0,0 +1,250 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v55/github"
+
+	jvspb "github.com/abcxyz/jvs/apis/v0"
+	"github.com/abcxyz/pkg/testutil"
+)
+
+// testHandleApproval returns a fake http func that serves the open test issue
+// authored by author, its reactions and comments, and the memberships of team
+// my-org/sre whose active members are given.
+func testHandleApproval(tb testing.TB, author, reactions, comments string, members ...string) func(w http.ResponseWriter, r *http.Request) {
+	tb.Helper()
+	issueHandler := testHandleIssueReturn(tb, []byte(fmt.Sprintf(`{"state": "open", "user": {"login": %q}}`, author)))
+	issuePath := fmt.Sprintf("%s/%s/%s/issues/%d", issueRESTAPIPathPrefix, testIssueOwner, testIssueRepoName, testExistIssueNumber)
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case issuePath + "/reactions":
+			fmt.Fprint(w, reactions)
+		case issuePath + "/comments":
+			fmt.Fprint(w, comments)
+		default:
+			for _, m := range members {
+				if r.URL.Path == "/orgs/my-org/teams/sre/memberships/"+m {
+					fmt.Fprint(w, `{"state": "active"}`)
+					return
+				}
+			}
+			if r.URL.Path == "/orgs/my-org/teams/sre" {
+				fmt.Fprint(w, `{"slug": "sre"}`)
+				return
+			}
+			if r.URL.Path == "/orgs/my-org/teams/sre/memberships/pending-user" {
+				fmt.Fprint(w, `{"state": "pending"}`)
+				return
+			}
+			if strings.HasPrefix(r.URL.Path, "/orgs/") {
+				http.Error(w, "not a member", http.StatusNotFound)
+				return
+			}
+			issueHandler(w, r)
+		}
+	}
+}
+
+func TestMatchIssue_Approval(t *testing.T) {
+	t.Parallel()
+
+	t1 := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+
+	reaction := func(login, content string, at time.Time) string {
+		return fmt.Sprintf(`{"user": {"login": %q}, "content": %q, "created_at": %q}`, login, content, at.Format(time.RFC3339))
+	}
+	comment := func(login, body string, at time.Time) string {
+		return fmt.Sprintf(`{"user": {"login": %q}, "body": %q, "created_at": %q}`, login, body, at.Format(time.RFC3339))
+	}
+
+	cases := []struct {
+		name         string
+		policy       *Policy
+		author       string
+		reactions    string
+		comments     string
+		members      []string
+		wantApprover string
+		wantAt       time.Time
+		wantErr      string
+		wantReason   Reason
+	}{
+		{
+			name:         "reaction",
+			policy:       &Policy{ApprovalTeams: []string{"my-org/sre"}},
+			author:       "dave",
+			reactions:    "[" + reaction("alice", "+1", t1) + "]",
+			comments:     "[]",
+			members:      []string{"alice"},
+			wantApprover: "alice",
+			wantAt:       t1,
+		},
+		{
+			name:         "comment",
+			policy:       &Policy{ApprovalTeams: []string{"my-org/sre"}},
+			author:       "dave",
+			reactions:    "[]",
+			comments:     "[" + comment("bob", "looks good\n/approve", t2) + "]",
+			members:      []string{"bob"},
+			wantApprover: "bob",
+			wantAt:       t2,
+		},
+		{
+			name:         "earliest_approval",
+			policy:       &Policy{ApprovalTeams: []string{"my-org/sre"}},
+			author:       "dave",
+			reactions:    "[" + reaction("alice", "+1", t2) + "]",
+			comments:     "[" + comment("bob", "/approve", t1) + "]",
+			members:      []string{"alice", "bob"},
+			wantApprover: "bob",
+			wantAt:       t1,
+		},
+		{
+			name:         "custom_reaction",
+			policy:       &Policy{ApprovalTeams: []string{"my-org/sre"}, ApprovalReaction: "rocket"},
+			author:       "dave",
+			reactions:    "[" + reaction("alice", "+1", t1) + "," + reaction("bob", "rocket", t2) + "]",
+			comments:     "[]",
+			members:      []string{"alice", "bob"},
+			wantApprover: "bob",
+			wantAt:       t2,
+		},
+		{
+			name:       "author_excluded",
+			policy:     &Policy{ApprovalTeams: []string{"my-org/sre"}},
+			author:     "Alice",
+			reactions:  "[" + reaction("alice", "+1", t1) + "]",
+			comments:   "[" + comment("alice", "/approve", t1) + "]",
+			members:    []string{"alice"},
+			wantErr:    `issue is not approved by a member of ["my-org/sre"] other than the issue author`,
+			wantReason: ReasonApprovalMissing,
+		},
+		{
+			name:       "no_author",
+			policy:     &Policy{ApprovalTeams: []string{"my-org/sre"}},
+			reactions:  "[" + reaction("alice", "+1", t1) + "]",
+			comments:   "[]",
+			members:    []string{"alice"},
+			wantErr:    "cannot be verified without the issue author",
+			wantReason: ReasonApprovalMissing,
+		},
+		{
+			name:       "not_member",
+			policy:     &Policy{ApprovalTeams: []string{"my-org/sre"}},
+			author:     "dave",
+			reactions:  "[" + reaction("mallory", "+1", t1) + "," + reaction("pending-user", "+1", t1) + "]",
+			comments:   "[" + comment("mallory", "/approve", t1) + "]",
+			wantErr:    "issue is not approved",
+			wantReason: ReasonApprovalMissing,
+		},
+		{
+			name:       "not_approval_comment",
+			policy:     &Policy{ApprovalTeams: []string{"my-org/sre"}},
+			author:     "dave",
+			reactions:  "[]",
+			comments:   "[" + comment("alice", "please /approve this", t1) + "]",
+			members:    []string{"alice"},
+			wantErr:    "issue is not approved",
+			wantReason: ReasonApprovalMissing,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			installation := testGitHubInstallation(t, http.StatusCreated)
+			hc := newTestServer(t, testHandleApproval(t, tc.author, tc.reactions, tc.comments, tc.members...))
+
+			v := NewValidator(github.NewClient(hc), installation, tc.policy)
+			got, err := v.MatchIssue(t.Context(), testGitHubIssueURL)
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if got, want := testReason(err), tc.wantReason; got != want {
+				t.Errorf("reason of %v got %q, want %q", err, got, want)
+			}
+			if got, want := got.Approver, tc.wantApprover; got != want {
+				t.Errorf("Approver got %q, want %q", got, want)
+			}
+			if diff := cmp.Diff(tc.wantAt, got.ApprovedAt); diff != "" {
+				t.Errorf("ApprovedAt unexpected diff (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_ApprovalRequesterAnnotation(t *testing.T) {
+	t.Parallel()
+
+	reaction := `[{"user": {"login": "alice"}, "content": "+1", "created_at": "2026-01-02T03:04:05Z"}]`
+
+	cases := []struct {
+		name       string
+		annotation map[string]string
+	}{
+		{
+			// Alice approves her own issue.
+			name: "no_requester",
+		},
+		{
+			// Alice approves her own issue and claims to be someone else.
+			name:       "spoofed_requester",
+			annotation: map[string]string{reqAnnotationKeyRequester: "bob"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			installation := testGitHubInstallation(t, http.StatusCreated)
+			hc := newTestServer(t, testHandleApproval(t, "alice", reaction, "[]", "alice"))
+
+			p := &GitHubPlugin{
+				categories: map[string]*pluginCategory{
+					githubCategory: {
+						validator: NewValidator(github.NewClient(hc), installation, &Policy{ApprovalTeams: []string{"my-org/sre"}}),
+					},
+				},
+			}
+			got, err := p.Validate(t.Context(), &jvspb.ValidateJustificationRequest{
+				Justification: &jvspb.Justification{
+					Category:   githubCategory,
+					Value:      testGitHubIssueURL,
+					Annotation: tc.annotation,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Validate() unexpected error: %v", err)
+			}
+			if got.GetValid() {
+				t.Errorf("Validate() got valid response, want invalid")
+			}
+			if errs, want := got.GetError(), "[APPROVAL_MISSING]"; len(errs) != 1 || !strings.HasPrefix(errs[0], want) {
+				t.Errorf("Validate() got errors %q, want one with prefix %q", errs, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// markdownHeadingRegExp matches ATX headings, which is also how issue
+	// forms render their field labels.
+	markdownHeadingRegExp = regexp.MustCompile(`^ {0,3}#{1,6}\s+(.*?)(\s+#+)?\s*$`)
+
+	// markdownCommentRegExp matches HTML comments, which issue templates use
+	// for instructions.
+	markdownCommentRegExp = regexp.MustCompile(`(?s)<!--.*?-->`)
+)
+
+// issueFormNoResponse is what issue forms render for empty optional fields.
+const issueFormNoResponse = "_No response_"
+
+// parseMarkdownSections returns the content of each section of the markdown
+// document keyed by the lower case heading. Headings inside fenced code blocks
+// are ignored.
+func parseMarkdownSections(body string) map[string]string {
+	sections := make(map[string]string)
+
+	var heading string
+	var content []string
+	flush := func() {
+		if heading != "" {
+			sections[heading] = strings.Join(content, "\n")
+		}
+		content = nil
+	}
+
+	inFence := false
+	for _, line := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n") {
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+		if !inFence {
+			if m := markdownHeadingRegExp.FindStringSubmatch(line); m != nil {
+				flush()
+				heading = strings.ToLower(strings.TrimSpace(m[1]))
+				continue
+			}
+		}
+		content = append(content, line)
+	}
+	flush()
+
+	return sections
+}
+
+// isEmptySection reports whether the section content is empty, ignoring
+// comments and the issue form placeholder for empty fields.
+func isEmptySection(content string) bool {
+	content = markdownCommentRegExp.ReplaceAllString(content, "")
+	content = strings.TrimSpace(content)
+	return content == "" || content == issueFormNoResponse
+}
+
+// validateIssueSections verifies the issue body has the required non-empty
+// sections, reporting all the missing ones.
+func (v *Validator) validateIssueSections(body string) error {
+	if len(v.policy.IssueRequiredSections) == 0 {
+		return nil
+	}
+	sections := parseMarkdownSections(body)
+	var missing []string
+	for _, s := range v.policy.IssueRequiredSections {
+		if content, ok := sections[strings.ToLower(strings.TrimSpace(s))]; !ok || isEmptySection(content) {
+			missing = append(missing, s)
+		}
+	}
+	if len(missing) > 0 {
+		return violationf(ruleIssueRequiredSections, "issue body is missing required sections %q", missing)
+	}
+	return nil
+}
+
+// validateIssueBodyPatterns verifies the issue body matches the body patterns,
+// reporting all the patterns it doesn't match.
+func (v *Validator) validateIssueBodyPatterns(body string) error {
+	var unmatched []string
+	for _, p := range v.policy.IssueBodyPatterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return fmt.Errorf("invalid issue body pattern %q: %w", p, err)
+		}
+		if !re.MatchString(body) {
+			unmatched = append(unmatched, p)
+		}
+	}
+	if len(unmatched) > 0 {
+		return violationf(ruleIssueBodyPatterns, "issue body doesn't match patterns %q", unmatched)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/abcxyz/pkg/testutil"
+)
+
+func TestParseMarkdownSections(t *testing.T) {
+	t.Parallel()
+
+	body := "intro\r\n" +
+		"## Impact ##\r\n" +
+		"Customers can't log in.\r\n" +
+		"### Rollback plan\r\n" +
+		"```\r\n" +
+		"# not a heading\r\n" +
+		"```\r\n" +
+		"#Not a heading either\r\n"
+
+	want := map[string]string{
+		"impact":        "Customers can't log in.",
+		"rollback plan": "```\n# not a heading\n```\n#Not a heading either\n",
+	}
+	if diff := cmp.Diff(want, parseMarkdownSections(body)); diff != "" {
+		t.Errorf("parseMarkdownSections() unexpected diff (-want,+got):\n%s", diff)
+	}
+}
+
+func TestValidateIssueBody(t *testing.T) {
+	t.Parallel()
+
+	// issueFormBody is how issue forms render their fields.
+	const issueFormBody = `### Impact
+
+Customers can't log in.
+
+### Customer ticket
+
+_No response_
+
+### Rollback plan
+
+<!-- Describe how to roll back. -->
+`
+
+	cases := []struct {
+		name       string
+		policy     *Policy
+		body       string
+		wantErr    string
+		wantReason Reason
+	}{
+		{
+			name:   "no_policy",
+			policy: &Policy{},
+			body:   "",
+		},
+		{
+			name:   "sections_present",
+			policy: &Policy{IssueRequiredSections: []string{"impact"}},
+			body:   issueFormBody,
+		},
+		{
+			name:       "sections_missing_or_empty",
+			policy:     &Policy{IssueRequiredSections: []string{"Impact", "Customer ticket", "Rollback plan", "Timeline"}},
+			body:       issueFormBody,
+			wantErr:    `issue body is missing required sections ["Customer ticket" "Rollback plan" "Timeline"]`,
+			wantReason: ReasonIssueBodyInvalid,
+		},
+		{
+			name:   "pattern_matches",
+			policy: &Policy{IssueBodyPatterns: []string{`(?i)customers`}},
+			body:   issueFormBody,
+		},
+		{
+			name: "patterns_not_matched",
+			policy: &Policy{
+				IssueBodyPatterns: []string{`(?i)customers`, `TICKET-[0-9]+`, `INC-[0-9]+`},
+			},
+			body:       issueFormBody,
+			wantErr:    `issue body doesn't match patterns ["TICKET-[0-9]+" "INC-[0-9]+"]`,
+			wantReason: ReasonIssueBodyInvalid,
+		},
+		{
+			name: "invalid_pattern",
+			policy: &Policy{
+				IssueBodyPatterns: []string{`(`},
+			},
+			body:    issueFormBody,
+			wantErr: "invalid issue body pattern",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			v := NewValidator(nil, nil, tc.policy)
+			err := errors.Join(v.validateIssueSections(tc.body), v.validateIssueBodyPatterns(tc.body))
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if got, want := testReason(err), tc.wantReason; got != want {
+				t.Errorf("reason of %v got %q, want %q", err, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/abcxyz/pkg/logging"
+)
+
+const (
+	// defaultCircuitBreakerThreshold is the default number of consecutive
+	// failed GitHub API calls opening the circuit breaker.
+	defaultCircuitBreakerThreshold = 5
+
+	// defaultCircuitBreakerCooldown is how long the circuit breaker stays open
+	// by default before probing GitHub again.
+	defaultCircuitBreakerCooldown = 30 * time.Second
+)
+
+// States of the circuit breaker.
+const (
+	breakerClosed   = "closed"
+	breakerOpen     = "open"
+	breakerHalfOpen = "half-open"
+)
+
+// errCircuitOpen is returned for GitHub API calls rejected by the open circuit
+// breaker.
+var errCircuitOpen = errors.New("github api is unavailable, circuit breaker is open")
+
+// breakerMetrics exposes the state of the circuit breaker of the process as the
+// "github_circuit_breaker" expvar.
+var breakerMetrics = expvar.NewMap("github_circuit_breaker")
+
+// CircuitBreaker fails GitHub API calls fast after consecutive failures, so
+// validations don't pile up waiting for their own timeouts during GitHub
+// incidents. After the cooldown a single probe call is let through, closing
+// the breaker if it succeeds and opening it again otherwise.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	// now returns the current time, it is overridden in tests.
+	now func() time.Time
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	// probing is whether the probe call of the half-open breaker is in flight.
+	probing bool
+}
+
+// NewCircuitBreaker creates a circuit breaker opening after threshold
+// consecutive failures, for the cooldown.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	b := &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+		state:     breakerClosed,
+	}
+	b.publish()
+	return b
+}
+
+// Transport wraps the base transport, failing fast with errCircuitOpen while the
+// breaker is open.
+func (b *CircuitBreaker) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &breakerTransport{breaker: b, base: base}
+}
+
+// allow reports whether a call is allowed, and whether it is the probe of the
+// half-open breaker.
+func (b *CircuitBreaker) allow(ctx context.Context) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			breakerMetrics.Add("rejected", 1)
+			return false, errCircuitOpen
+		}
+		b.setState(ctx, breakerHalfOpen)
+		fallthrough
+	case breakerHalfOpen:
+		if b.probing {
+			breakerMetrics.Add("rejected", 1)
+			return false, errCircuitOpen
+		}
+		b.probing = true
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// record records the outcome of an allowed call. Calls canceled by the caller
+// are neither successes nor failures.
+func (b *CircuitBreaker) record(ctx context.Context, probe, failed, canceled bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		b.probing = false
+	}
+	switch {
+	case canceled:
+	case failed:
+		b.failures++
+		breakerMetrics.Add("failures", 1)
+		if probe || (b.state == breakerClosed && b.failures >= b.threshold) {
+			b.openedAt = b.now()
+			b.setState(ctx, breakerOpen)
+			breakerMetrics.Add("trips", 1)
+		}
+	default:
+		b.failures = 0
+		if b.state != breakerClosed {
+			b.setState(ctx, breakerClosed)
+		}
+	}
+	b.publish()
+}
+
+// setState transitions the breaker to the state and logs it. The caller must
+// hold the lock.
+func (b *CircuitBreaker) setState(ctx context.Context, state string) {
+	logger := logging.FromContext(ctx)
+	switch state {
+	case breakerOpen:
+		logger.WarnContext(ctx, "github circuit breaker opened",
+			"consecutive_failures", b.failures,
+			"cooldown", b.cooldown)
+	case breakerHalfOpen:
+		logger.InfoContext(ctx, "github circuit breaker half-open, probing github")
+	default:
+		logger.InfoContext(ctx, "github circuit breaker closed")
+	}
+	b.state = state
+	b.publish()
+}
+
+// publish updates the metrics with the state of the breaker. The caller must
+// hold the lock.
+func (b *CircuitBreaker) publish() {
+	state := new(expvar.String)
+	state.Set(b.state)
+	breakerMetrics.Set("state", state)
+	failures := new(expvar.Int)
+	failures.Set(int64(b.failures))
+	breakerMetrics.Set("consecutive_failures", failures)
+}
+
+// breakerTransport is the http.RoundTripper guarded by the circuit breaker.
+type breakerTransport struct {
+	breaker *CircuitBreaker
+	base    http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper. Transport errors, timeouts and 5xx
+// responses are failures.
+func (t *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	probe, err := t.breaker.allow(ctx)
+	if err != nil {
+		// The http.Client wraps the error with the request.
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	failed := err != nil || resp.StatusCode >= http.StatusInternalServerError
+	canceled := errors.Is(ctx.Err(), context.Canceled)
+	t.breaker.record(ctx, probe, failed, canceled)
+	return resp, err //nolint:wrapcheck // Want passthrough
+}
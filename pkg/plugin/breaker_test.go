@@ -0,0 +1,210 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	jvspb "github.com/abcxyz/jvs/apis/v0"
+)
+
+// testRoundTripper responds to every request with the status code or error.
+type testRoundTripper struct {
+	code  int
+	err   error
+	calls int
+}
+
+func (t *testRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.calls++
+	if t.err != nil {
+		return nil, t.err
+	}
+	return &http.Response{StatusCode: t.code, Body: http.NoBody, Request: req}, nil
+}
+
+// testBreakerCall is a GitHub API call through the circuit breaker.
+type testBreakerCall struct {
+	// elapsed is the time passed before the call.
+	elapsed time.Duration
+	code    int
+	err     error
+	// canceled cancels the context of the call.
+	canceled bool
+	// wantOpen is whether the call is rejected by the open breaker.
+	wantOpen bool
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	failure := testBreakerCall{code: http.StatusBadGateway}
+	success := testBreakerCall{code: http.StatusOK}
+	rejected := testBreakerCall{code: http.StatusOK, wantOpen: true}
+
+	cases := []struct {
+		name      string
+		calls     []testBreakerCall
+		wantState string
+	}{
+		{
+			name:      "closed",
+			calls:     []testBreakerCall{success, failure, success},
+			wantState: breakerClosed,
+		},
+		{
+			name:      "opens_after_threshold",
+			calls:     []testBreakerCall{failure, failure, failure, rejected},
+			wantState: breakerOpen,
+		},
+		{
+			name:      "success_resets_failures",
+			calls:     []testBreakerCall{failure, failure, success, failure, failure, success},
+			wantState: breakerClosed,
+		},
+		{
+			name: "transport_errors",
+			calls: []testBreakerCall{
+				{err: errors.New("connection refused")},
+				{err: context.DeadlineExceeded},
+				{err: errors.New("connection reset")},
+				rejected,
+			},
+			wantState: breakerOpen,
+		},
+		{
+			name: "client_errors",
+			calls: []testBreakerCall{
+				{code: http.StatusNotFound},
+				{code: http.StatusNotFound},
+				{code: http.StatusUnprocessableEntity},
+				success,
+			},
+			wantState: breakerClosed,
+		},
+		{
+			name: "canceled_calls",
+			calls: []testBreakerCall{
+				{err: context.Canceled, canceled: true},
+				{err: context.Canceled, canceled: true},
+				{err: context.Canceled, canceled: true},
+				success,
+			},
+			wantState: breakerClosed,
+		},
+		{
+			name: "probe_succeeds",
+			calls: []testBreakerCall{
+				failure, failure, failure,
+				{elapsed: 29 * time.Second, wantOpen: true},
+				{elapsed: time.Second, code: http.StatusOK},
+				success,
+			},
+			wantState: breakerClosed,
+		},
+		{
+			name: "probe_fails",
+			calls: []testBreakerCall{
+				failure, failure, failure,
+				{elapsed: 30 * time.Second, code: http.StatusServiceUnavailable},
+				rejected,
+			},
+			wantState: breakerOpen,
+		},
+		{
+			name: "probe_canceled",
+			calls: []testBreakerCall{
+				failure, failure, failure,
+				{elapsed: 30 * time.Second, err: context.Canceled, canceled: true},
+				success,
+			},
+			wantState: breakerClosed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+			b := NewCircuitBreaker(3, 30*time.Second)
+			b.now = func() time.Time { return now }
+			base := &testRoundTripper{}
+			client := &http.Client{Transport: b.Transport(base)}
+
+			for i, call := range tc.calls {
+				now = now.Add(call.elapsed)
+				base.code, base.err = call.code, call.err
+
+				ctx, cancel := context.WithCancel(t.Context())
+				if call.canceled {
+					cancel()
+				}
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/repos/test-owner/test-repo", nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				calls := base.calls
+				resp, err := client.Do(req)
+				cancel()
+				if resp != nil {
+					resp.Body.Close()
+				}
+
+				if got := errors.Is(err, errCircuitOpen); got != call.wantOpen {
+					t.Errorf("call %d got error %v, want circuit open %t", i, err, call.wantOpen)
+				}
+				if got := base.calls > calls; got == call.wantOpen {
+					t.Errorf("call %d reached github %t, want %t", i, got, !call.wantOpen)
+				}
+			}
+			if b.state != tc.wantState {
+				t.Errorf("state got %q, want %q", b.state, tc.wantState)
+			}
+		})
+	}
+}
+
+func TestValidate_CircuitOpen(t *testing.T) {
+	t.Parallel()
+
+	p := &GitHubPlugin{
+		categories: map[string]*pluginCategory{
+			githubCategory: {
+				validator: &testReferenceMatcher{
+					rErr: fmt.Errorf("failed to get issue info: %w", &url.Error{Op: "Get", URL: "https://api.github.com", Err: errCircuitOpen}),
+				},
+			},
+		},
+	}
+	_, err := p.Validate(t.Context(), &jvspb.ValidateJustificationRequest{
+		Justification: &jvspb.Justification{
+			Category: githubCategory,
+			Value:    testGitHubIssueURL,
+		},
+	})
+	if got, want := status.Code(err), codes.Unavailable; got != want {
+		t.Errorf("Validate() got code %s, want %s, error: %v", got, want, err)
+	}
+}
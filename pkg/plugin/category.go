@@ -0,0 +1,171 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/abcxyz/pkg/cli"
+)
+
+// executablePrefix is the prefix of plugin executables. JVS names the category
+// served by a plugin after its executable without the prefix.
+const executablePrefix = "jvs-plugin-"
+
+// categoryNamePattern is the pattern of category names.
+var categoryNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// CategoryConfig is a justification category validated by the plugin, in
+// addition to the "github" category configured by the plugin config.
+type CategoryConfig struct {
+	// Name is the justification category.
+	Name string
+	// DisplayName is for display, e.g. for the web UI.
+	DisplayName string
+	// Hint is for what value to put as the justification.
+	Hint string
+	// ExampleURL is the example justification available to the display name
+	// and hint templates.
+	ExampleURL string
+	// Localized are the localized display names and hints, by BCP 47
+	// language tag.
+	Localized map[string]*LocalizedText
+	// Policy is the validation policy of the category.
+	Policy Policy
+}
+
+// categoriesFile is the format of the categories file.
+type categoriesFile struct {
+	// Localized are the localized display names and hints of the "github"
+	// category.
+	Localized map[string]*LocalizedText `yaml:"localized"`
+
+	Categories []*struct {
+		Name        string                    `yaml:"name"`
+		DisplayName string                    `yaml:"display_name"`
+		Hint        string                    `yaml:"hint"`
+		ExampleURL  string                    `yaml:"example_url"`
+		Localized   map[string]*LocalizedText `yaml:"localized"`
+		// Policy overrides the policy environment variables, e.g.
+		// GITHUB_ISSUE_TYPES, for the category.
+		Policy map[string]string `yaml:"policy"`
+	} `yaml:"categories"`
+}
+
+// LoadCategories loads the additional categories, and the localized display
+// names and hints of the "github" category, from the categories file, if
+// any, and defaults the served category to the one JVS derives from the
+// executable name. Category policies inherit the policy options looked up with
+// lookupEnv, and override them with their own.
+func (cfg *PluginConfig) LoadCategories(lookupEnv cli.LookupEnvFunc) error {
+	if cfg.GitHubPluginCategory == "" {
+		cfg.GitHubPluginCategory = categoryFromExecutable(os.Args[0])
+	}
+
+	cfg.Categories = nil
+	cfg.Localized = nil
+	names := []string{githubCategory}
+	if cfg.GitHubCategoriesFile != "" {
+		b, err := os.ReadFile(cfg.GitHubCategoriesFile)
+		if err != nil {
+			return fmt.Errorf("failed to read categories file: %w", err)
+		}
+		var f categoriesFile
+		if err := yaml.Unmarshal(b, &f); err != nil {
+			return fmt.Errorf("failed to parse categories file: %w", err)
+		}
+		cfg.Localized = f.Localized
+
+		for _, c := range f.Categories {
+			if !categoryNamePattern.MatchString(c.Name) {
+				return fmt.Errorf("category name %q must match %s", c.Name, categoryNamePattern)
+			}
+			if slices.Contains(names, c.Name) {
+				return fmt.Errorf("category %q is defined more than once", c.Name)
+			}
+			names = append(names, c.Name)
+			if c.DisplayName == "" || c.Hint == "" {
+				return fmt.Errorf("category %q must have a display_name and a hint", c.Name)
+			}
+
+			policy, err := parseCategoryPolicy(c.Policy, lookupEnv)
+			if err != nil {
+				return fmt.Errorf("invalid policy of category %q: %w", c.Name, err)
+			}
+			cfg.Categories = append(cfg.Categories, &CategoryConfig{
+				Name:        c.Name,
+				DisplayName: c.DisplayName,
+				Hint:        c.Hint,
+				ExampleURL:  c.ExampleURL,
+				Localized:   c.Localized,
+				Policy:      *policy,
+			})
+		}
+	}
+
+	if !slices.Contains(names, cfg.GitHubPluginCategory) {
+		return fmt.Errorf("GITHUB_PLUGIN_CATEGORY %q must be one of %q", cfg.GitHubPluginCategory, names)
+	}
+	return nil
+}
+
+// parseCategoryPolicy parses the policy options, rejecting options which are
+// not policy options.
+func parseCategoryPolicy(options map[string]string, lookupEnv cli.LookupEnvFunc) (*Policy, error) {
+	looked := make(map[string]bool)
+	record := func(k string) (string, bool) {
+		looked[k] = true
+		return "", false
+	}
+
+	policy := &Policy{}
+	set := policy.ToFlags(cli.NewFlagSet(cli.WithLookupEnv(cli.MultiLookuper(
+		record, cli.MapLookuper(options), lookupEnv))))
+	if err := set.Parse(nil); err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+
+	var unknown []string
+	for k := range options {
+		if !looked[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+		return nil, fmt.Errorf("unknown policy options %q", unknown)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err //nolint:wrapcheck // Want passthrough
+	}
+	return policy, nil
+}
+
+// categoryFromExecutable returns the category JVS derives from the executable
+// path, or "github" if the executable is not named like a plugin.
+func categoryFromExecutable(path string) string {
+	if c, ok := strings.CutPrefix(filepath.Base(path), executablePrefix); ok && c != "" {
+		return c
+	}
+	return githubCategory
+}
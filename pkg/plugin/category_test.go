@@ -0,0 +1,229 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/abcxyz/pkg/cli"
+	"github.com/abcxyz/pkg/testutil"
+)
+
+func TestPluginConfig_LoadCategories(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name          string
+		category      string
+		file          string
+		env           map[string]string
+		wantCategory  string
+		wantLocalized map[string]*LocalizedText
+		want          []*CategoryConfig
+		wantErr       string
+	}{
+		{
+			name:         "no_file",
+			wantCategory: githubCategory,
+		},
+		{
+			name:     "categories",
+			category: "github-incident",
+			file: `
+localized:
+  de:
+    hint: Issue-URL
+categories:
+  - name: github-incident
+    display_name: GitHub incident
+    hint: Incident issue URL
+    example_url: https://github.com/my-org/incidents/issues/1
+    localized:
+      de:
+        display_name: GitHub-Vorfall
+    policy:
+      GITHUB_ISSUE_TYPES: Incident
+  - name: github-pr
+    display_name: GitHub pull request
+    hint: Pull request URL
+`,
+			env: map[string]string{
+				"GITHUB_TEAM_CACHE_TTL": "10m",
+				"GITHUB_ISSUE_TYPES":    "Change",
+			},
+			wantCategory: "github-incident",
+			wantLocalized: map[string]*LocalizedText{
+				"de": {Hint: "Issue-URL"},
+			},
+			want: []*CategoryConfig{
+				{
+					Name:        "github-incident",
+					DisplayName: "GitHub incident",
+					Hint:        "Incident issue URL",
+					ExampleURL:  "https://github.com/my-org/incidents/issues/1",
+					Localized: map[string]*LocalizedText{
+						"de": {DisplayName: "GitHub-Vorfall"},
+					},
+					Policy: Policy{
+						ReferenceMode:      referenceModeAll,
+						MaxReferences:      defaultMaxReferences,
+						RepositoryCacheTTL: defaultRepositoryCacheTTL,
+						DiscussionAnswered: discussionAnsweredAny,
+						ProjectStatusField: defaultProjectStatusField,
+						IssueTypes:         []string{"Incident"},
+						TeamCacheTTL:       10 * time.Minute,
+						ApprovalReaction:   defaultApprovalReaction,
+						ApprovalComment:    defaultApprovalComment,
+						DegradedWindow:     defaultDegradedWindow,
+					},
+				},
+				{
+					Name:        "github-pr",
+					DisplayName: "GitHub pull request",
+					Hint:        "Pull request URL",
+					Policy: Policy{
+						ReferenceMode:      referenceModeAll,
+						MaxReferences:      defaultMaxReferences,
+						RepositoryCacheTTL: defaultRepositoryCacheTTL,
+						DiscussionAnswered: discussionAnsweredAny,
+						ProjectStatusField: defaultProjectStatusField,
+						IssueTypes:         []string{"Change"},
+						TeamCacheTTL:       10 * time.Minute,
+						ApprovalReaction:   defaultApprovalReaction,
+						ApprovalComment:    defaultApprovalComment,
+						DegradedWindow:     defaultDegradedWindow,
+					},
+				},
+			},
+		},
+		{
+			name:     "unknown_served_category",
+			category: "github-pr",
+			wantErr:  `GITHUB_PLUGIN_CATEGORY "github-pr" must be one of ["github"]`,
+		},
+		{
+			name: "duplicate_category",
+			file: `
+categories:
+  - name: github
+    display_name: GitHub
+    hint: Issue URL
+`,
+			wantErr: `category "github" is defined more than once`,
+		},
+		{
+			name: "invalid_name",
+			file: `
+categories:
+  - name: GitHub Incident
+`,
+			wantErr: `category name "GitHub Incident" must match`,
+		},
+		{
+			name: "missing_hint",
+			file: `
+categories:
+  - name: github-incident
+    display_name: GitHub incident
+`,
+			wantErr: `category "github-incident" must have a display_name and a hint`,
+		},
+		{
+			name: "unknown_policy_option",
+			file: `
+categories:
+  - name: github-incident
+    display_name: GitHub incident
+    hint: Incident issue URL
+    policy:
+      GITHUB_ISSUE_TYPE: Incident
+`,
+			wantErr: `invalid policy of category "github-incident": unknown policy options ["GITHUB_ISSUE_TYPE"]`,
+		},
+		{
+			name: "invalid_policy",
+			file: `
+categories:
+  - name: github-incident
+    display_name: GitHub incident
+    hint: Incident issue URL
+    policy:
+      GITHUB_DISCUSSION_ANSWERED: maybe
+`,
+			wantErr: `invalid policy of category "github-incident": GITHUB_DISCUSSION_ANSWERED must be one of`,
+		},
+		{
+			name:    "invalid_yaml",
+			file:    "categories: {",
+			wantErr: "failed to parse categories file",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &PluginConfig{GitHubPluginCategory: tc.category}
+			if tc.file != "" {
+				cfg.GitHubCategoriesFile = filepath.Join(t.TempDir(), "categories.yaml")
+				if err := os.WriteFile(cfg.GitHubCategoriesFile, []byte(tc.file), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := cfg.LoadCategories(cli.MapLookuper(tc.env))
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if tc.wantErr != "" {
+				return
+			}
+			if got, want := cfg.GitHubPluginCategory, tc.wantCategory; got != want {
+				t.Errorf("GitHubPluginCategory got %q, want %q", got, want)
+			}
+			if diff := cmp.Diff(tc.wantLocalized, cfg.Localized); diff != "" {
+				t.Errorf("Localized unexpected diff (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, cfg.Categories); diff != "" {
+				t.Errorf("Categories unexpected diff (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCategoryFromExecutable(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		path string
+		want string
+	}{
+		{path: "/var/jvs/plugins/jvs-plugin-github", want: "github"},
+		{path: "/var/jvs/plugins/jvs-plugin-github-incident", want: "github-incident"},
+		{path: "jvs-plugin-", want: githubCategory},
+		{path: "/usr/bin/plugin.test", want: githubCategory},
+	}
+
+	for _, tc := range cases {
+		if got := categoryFromExecutable(tc.path); got != tc.want {
+			t.Errorf("categoryFromExecutable(%q) = %q, want %q", tc.path, got, tc.want)
+		}
+	}
+}
@@ -18,10 +18,14 @@ package plugin
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/abcxyz/pkg/cli"
 )
 
+// DefaultGitHubAPIBaseURL is the API base URL of github.com.
+const DefaultGitHubAPIBaseURL = "https://api.github.com"
+
 // PluginConfig defines the set over environment variables required
 // for running the plugin.
 type PluginConfig struct {
@@ -32,19 +36,145 @@ type PluginConfig struct {
 	// The private Key PEM obtained for github app.
 	GitHubAppPrivateKeyPEM string
 
-	// GitHubPluginDisplayName is for display, e.g. for the web UI.
+	// GitHubPluginDisplayName is for display, e.g. for the web UI. It and
+	// GitHubPluginHint are literal text unless GitHubPluginUITemplates is set.
 	GitHubPluginDisplayName string
 
 	// GitHubPluginHint is for what value to put as the justification.
 	GitHubPluginHint string
 
+	// GitHubPluginUITemplates enables rendering the display names and hints
+	// of all categories as templates with the policy, see uiTemplateData.
+	GitHubPluginUITemplates bool
+
+	// GitHubPluginExampleURL is the example justification available to the
+	// display name and hint templates as {{.ExampleURL}}.
+	GitHubPluginExampleURL string
+
+	// Localized are the localized display names and hints of the "github"
+	// category, by BCP 47 language tag, loaded from GitHubCategoriesFile by
+	// LoadCategories. They are currently unavailable with JVS, which doesn't
+	// send the languages accepted by the UI.
+	Localized map[string]*LocalizedText
+
+	// GitHubPluginCategory is the category served to the web UI. JVS runs one
+	// plugin process per category, named after the executable, so it defaults
+	// to the executable name without the "jvs-plugin-" prefix, or "github".
+	GitHubPluginCategory string
+
+	// GitHubCategoriesFile is the path of a YAML file defining additional
+	// categories, each with its own display name, hint and policy.
+	GitHubCategoriesFile string
+
+	// Categories are the additional categories loaded from
+	// GitHubCategoriesFile by LoadCategories.
+	Categories []*CategoryConfig
+
 	// GitHubAPIBaseURL is the base URL, primarily used for overriding during
 	// testing and for custom GHES installations.
 	GitHubAPIBaseURL string
+
+	// GitHubAuditComment enables posting a rolling audit comment on the issue
+	// after each successful validation.
+	GitHubAuditComment bool
+
+	// GitHubUsedLabel is the label added to the issue after each successful
+	// validation. Labelling is disabled when empty.
+	GitHubUsedLabel string
+
+	// GitHubUsedLabelDryRun only logs the issues that would be labelled.
+	GitHubUsedLabelDryRun bool
+
+	// GitHubSuggestPort is the port of the issue suggestion endpoint. The
+	// endpoint is disabled when empty.
+	GitHubSuggestPort string
+
+	// GitHubSuggestHost is the host the issue suggestion endpoint listens on.
+	// Defaults to the loopback address.
+	GitHubSuggestHost string
+
+	// GitHubSuggestToken is the bearer token authenticating callers of the
+	// issue suggestion endpoint. It is required unless the endpoint listens on
+	// a loopback address.
+	GitHubSuggestToken string
+
+	// GitHubSuggestOwners are the organizations and users whose issues are
+	// suggested.
+	GitHubSuggestOwners []string
+
+	// GitHubSuggestRateLimit is the number of suggestion requests allowed per
+	// requester per minute. Defaults to 30.
+	GitHubSuggestRateLimit int
+
+	// GitHubSuggestGlobalRateLimit is the number of suggestion requests
+	// allowed per minute across all requesters. Defaults to 300.
+	GitHubSuggestGlobalRateLimit int
+
+	// GitHubCircuitBreakerThreshold is the number of consecutive failed
+	// GitHub API calls opening the circuit breaker. Defaults to 5.
+	GitHubCircuitBreakerThreshold int
+
+	// GitHubCircuitBreakerCooldown is how long the circuit breaker stays open
+	// before probing GitHub again. Defaults to 30 seconds.
+	GitHubCircuitBreakerCooldown time.Duration
+
+	// GitHubMetricsPort is the port of the metrics endpoint. The endpoint is
+	// disabled when empty.
+	GitHubMetricsPort string
+
+	// Policy is the validation policy applied to justifications.
+	Policy Policy
 }
 
 // Validate validates if the config is valid.
 func (cfg *PluginConfig) Validate() error {
+	rErr := cfg.ValidateGitHubApp()
+	if cfg.GitHubPluginDisplayName == "" {
+		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_PLUGIN_DISPLAY_NAME is empty"))
+	}
+	if cfg.GitHubPluginHint == "" {
+		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_PLUGIN_HINT is empty"))
+	}
+	if cfg.GitHubSuggestPort != "" && len(cfg.GitHubSuggestOwners) == 0 {
+		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_SUGGEST_OWNERS is required when GITHUB_SUGGEST_PORT is set"))
+	}
+	if cfg.GitHubSuggestHost == "" {
+		cfg.GitHubSuggestHost = defaultSuggestHost
+	}
+	if cfg.GitHubSuggestPort != "" && cfg.GitHubSuggestToken == "" && !isLoopbackHost(cfg.GitHubSuggestHost) {
+		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_SUGGEST_TOKEN is required when GITHUB_SUGGEST_HOST is not a loopback address, got %q", cfg.GitHubSuggestHost))
+	}
+	if cfg.GitHubSuggestRateLimit == 0 {
+		cfg.GitHubSuggestRateLimit = defaultSuggestRateLimit
+	} else if cfg.GitHubSuggestRateLimit < 0 {
+		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_SUGGEST_RATE_LIMIT must be positive, got %d", cfg.GitHubSuggestRateLimit))
+	}
+	if cfg.GitHubSuggestGlobalRateLimit == 0 {
+		cfg.GitHubSuggestGlobalRateLimit = defaultSuggestGlobalRateLimit
+	} else if cfg.GitHubSuggestGlobalRateLimit < 0 {
+		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_SUGGEST_GLOBAL_RATE_LIMIT must be positive, got %d", cfg.GitHubSuggestGlobalRateLimit))
+	}
+	if cfg.GitHubCircuitBreakerThreshold == 0 {
+		cfg.GitHubCircuitBreakerThreshold = defaultCircuitBreakerThreshold
+	} else if cfg.GitHubCircuitBreakerThreshold < 0 {
+		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_CIRCUIT_BREAKER_THRESHOLD must be positive, got %d", cfg.GitHubCircuitBreakerThreshold))
+	}
+	if cfg.GitHubCircuitBreakerCooldown == 0 {
+		cfg.GitHubCircuitBreakerCooldown = defaultCircuitBreakerCooldown
+	} else if cfg.GitHubCircuitBreakerCooldown < 0 {
+		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_CIRCUIT_BREAKER_COOLDOWN must be positive, got %s", cfg.GitHubCircuitBreakerCooldown))
+	}
+	if err := cfg.Policy.Validate(); err != nil {
+		rErr = errors.Join(rErr, err)
+	}
+
+	return rErr
+}
+
+// ValidateGitHubApp validates only the parts of the config required to
+// authenticate as the GitHub App, for commands that talk to GitHub but do not
+// serve the plugin.
+func (cfg *PluginConfig) ValidateGitHubApp() error {
 	var rErr error
 	if cfg.GitHubAppID == "" {
 		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_APP_ID is empty"))
@@ -55,14 +185,8 @@ func (cfg *PluginConfig) Validate() error {
 	if cfg.GitHubAppPrivateKeyPEM == "" {
 		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_APP_PRIVATE_KEY_PEM is empty"))
 	}
-	if cfg.GitHubPluginDisplayName == "" {
-		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_PLUGIN_DISPLAY_NAME is empty"))
-	}
-	if cfg.GitHubPluginHint == "" {
-		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_PLUGIN_HINT is empty"))
-	}
 	if cfg.GitHubAPIBaseURL == "" {
-		cfg.GitHubAPIBaseURL = "https://api.github.com"
+		cfg.GitHubAPIBaseURL = DefaultGitHubAPIBaseURL
 	}
 
 	return rErr
@@ -100,14 +224,49 @@ func (cfg *PluginConfig) ToFlags(set *cli.FlagSet) *cli.FlagSet {
 		Name:   "github-plugin-display-name",
 		Target: &cfg.GitHubPluginDisplayName,
 		EnvVar: "GITHUB_PLUGIN_DISPLAY_NAME",
-		Usage:  "The name for display, e.g. for the web UI.",
+		Usage:  "The name for display, e.g. for the web UI. It is a Go template rendered with the policy if github-plugin-ui-templates is set.",
 	})
 
 	f.StringVar(&cli.StringVar{
 		Name:   "github-plugin-hint",
 		Target: &cfg.GitHubPluginHint,
 		EnvVar: "GITHUB_PLUGIN_HINT",
-		Usage:  "Hint for what value to put as the justification.",
+		Usage: "Hint for what value to put as the justification. It is a Go " +
+			"template rendered with the policy if github-plugin-ui-templates is set, e.g. " +
+			`"Issue of type {{join .Policy.IssueTypes}}, like {{.ExampleURL}}".`,
+	})
+
+	f.BoolVar(&cli.BoolVar{
+		Name:   "github-plugin-ui-templates",
+		Target: &cfg.GitHubPluginUITemplates,
+		EnvVar: "GITHUB_PLUGIN_UI_TEMPLATES",
+		Usage: "Render the display names and hints of all categories as Go " +
+			"templates with the policy. They are literal text otherwise.",
+	})
+
+	f.StringVar(&cli.StringVar{
+		Name:    "github-plugin-example-url",
+		Target:  &cfg.GitHubPluginExampleURL,
+		EnvVar:  "GITHUB_PLUGIN_EXAMPLE_URL",
+		Example: "https://github.com/my-org/incidents/issues/1",
+		Usage:   fmt.Sprintf("Example justification available to the display name and hint templates as {{.ExampleURL}}. Defaults to %q.", defaultExampleURL),
+	})
+
+	f.StringVar(&cli.StringVar{
+		Name:    "github-plugin-category",
+		Target:  &cfg.GitHubPluginCategory,
+		EnvVar:  "GITHUB_PLUGIN_CATEGORY",
+		Example: "github-incident",
+		Usage: "The category served to the web UI. Defaults to the executable " +
+			`name without the "jvs-plugin-" prefix, or "github".`,
+	})
+
+	f.StringVar(&cli.StringVar{
+		Name:    "github-plugin-categories-file",
+		Target:  &cfg.GitHubCategoriesFile,
+		EnvVar:  "GITHUB_PLUGIN_CATEGORIES_FILE",
+		Example: "/etc/jvs/github-categories.yaml",
+		Usage:   "Path of a YAML file defining additional categories, each with its own display name, hint and policy.",
 	})
 
 	f.StringVar(&cli.StringVar{
@@ -117,5 +276,114 @@ func (cfg *PluginConfig) ToFlags(set *cli.FlagSet) *cli.FlagSet {
 		Usage:  "Full URL, including the protocol for the API base to the GitHub server.",
 	})
 
-	return set
+	f = set.NewSection("WRITE-BACK OPTIONS")
+
+	f.BoolVar(&cli.BoolVar{
+		Name:   "github-audit-comment",
+		Target: &cfg.GitHubAuditComment,
+		EnvVar: "GITHUB_AUDIT_COMMENT",
+		Usage: "Post or update an audit comment on the issue after each " +
+			"successful validation. Requires the github app to have issue " +
+			"write permission.",
+	})
+
+	f.StringVar(&cli.StringVar{
+		Name:    "github-used-label",
+		Target:  &cfg.GitHubUsedLabel,
+		EnvVar:  "GITHUB_USED_LABEL",
+		Example: "jvs:used",
+		Usage: "Label to add to the issue after each successful validation. " +
+			"Requires the github app to have issue write permission.",
+	})
+
+	f.BoolVar(&cli.BoolVar{
+		Name:   "github-used-label-dry-run",
+		Target: &cfg.GitHubUsedLabelDryRun,
+		EnvVar: "GITHUB_USED_LABEL_DRY_RUN",
+		Usage:  "Only log the issues that would be labelled with the used label.",
+	})
+
+	f = set.NewSection("SUGGESTION OPTIONS")
+
+	f.StringVar(&cli.StringVar{
+		Name:    "github-suggest-port",
+		Target:  &cfg.GitHubSuggestPort,
+		EnvVar:  "GITHUB_SUGGEST_PORT",
+		Example: "8081",
+		Usage: "Port of the HTTP endpoint suggesting issues which pass " +
+			"validation. The endpoint is disabled if unset.",
+	})
+
+	f.StringVar(&cli.StringVar{
+		Name:    "github-suggest-host",
+		Target:  &cfg.GitHubSuggestHost,
+		EnvVar:  "GITHUB_SUGGEST_HOST",
+		Example: "0.0.0.0",
+		Usage: fmt.Sprintf("Host the HTTP endpoint suggesting issues listens on. "+
+			"Defaults to %s, only reachable from the same host.", defaultSuggestHost),
+	})
+
+	f.StringVar(&cli.StringVar{
+		Name:    "github-suggest-token",
+		Target:  &cfg.GitHubSuggestToken,
+		EnvVar:  "GITHUB_SUGGEST_TOKEN",
+		Example: "my-secret",
+		Usage: "Bearer token callers of the HTTP endpoint suggesting issues " +
+			"must authenticate with. Required unless the endpoint listens on " +
+			"a loopback address.",
+	})
+
+	f.StringSliceVar(&cli.StringSliceVar{
+		Name:    "github-suggest-owners",
+		Target:  &cfg.GitHubSuggestOwners,
+		EnvVar:  "GITHUB_SUGGEST_OWNERS",
+		Example: "my-org",
+		Usage:   "Organizations and users whose issues are suggested.",
+	})
+
+	f.IntVar(&cli.IntVar{
+		Name:    "github-suggest-rate-limit",
+		Target:  &cfg.GitHubSuggestRateLimit,
+		EnvVar:  "GITHUB_SUGGEST_RATE_LIMIT",
+		Example: "60",
+		Usage:   fmt.Sprintf("Suggestion requests allowed per requester per minute. Defaults to %d.", defaultSuggestRateLimit),
+	})
+
+	f.IntVar(&cli.IntVar{
+		Name:    "github-suggest-global-rate-limit",
+		Target:  &cfg.GitHubSuggestGlobalRateLimit,
+		EnvVar:  "GITHUB_SUGGEST_GLOBAL_RATE_LIMIT",
+		Example: "600",
+		Usage:   fmt.Sprintf("Suggestion requests allowed per minute across all requesters. Defaults to %d.", defaultSuggestGlobalRateLimit),
+	})
+
+	f = set.NewSection("CIRCUIT BREAKER OPTIONS")
+
+	f.IntVar(&cli.IntVar{
+		Name:    "github-circuit-breaker-threshold",
+		Target:  &cfg.GitHubCircuitBreakerThreshold,
+		EnvVar:  "GITHUB_CIRCUIT_BREAKER_THRESHOLD",
+		Example: "10",
+		Usage: fmt.Sprintf("Number of consecutive failed GitHub API calls, with transport errors, timeouts or 5xx responses, "+
+			"after which calls fail fast until GitHub recovers. Defaults to %d.", defaultCircuitBreakerThreshold),
+	})
+
+	f.DurationVar(&cli.DurationVar{
+		Name:    "github-circuit-breaker-cooldown",
+		Target:  &cfg.GitHubCircuitBreakerCooldown,
+		EnvVar:  "GITHUB_CIRCUIT_BREAKER_COOLDOWN",
+		Example: "1m",
+		Usage:   fmt.Sprintf("How long GitHub API calls fail fast before probing GitHub again. Defaults to %s.", defaultCircuitBreakerCooldown),
+	})
+
+	f.StringVar(&cli.StringVar{
+		Name:    "github-metrics-port",
+		Target:  &cfg.GitHubMetricsPort,
+		EnvVar:  "GITHUB_METRICS_PORT",
+		Example: "9090",
+		Usage: "Port of the HTTP endpoint serving metrics, including the circuit breaker state, " +
+			"as JSON at /debug/vars. The endpoint is disabled if unset.",
+	})
+
+	return cfg.Policy.ToFlags(set)
 }
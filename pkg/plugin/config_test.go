@@ -17,6 +17,7 @@ package plugin
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -171,6 +172,94 @@ func TestPluginConfig_Validate(t *testing.T) {
 			},
 			wantErr: "GITHUB_PLUGIN_HINT is empty",
 		},
+		{
+			name: "suggest_port_without_owners",
+			cfg: &PluginConfig{
+				GitHubAppID:             testGitHubAppID,
+				GitHubAppInstallationID: testGitHubAppInstallationID,
+				GitHubAppPrivateKeyPEM:  testPrivateKeyString,
+				GitHubPluginDisplayName: testGitHubPluginDisplayName,
+				GitHubPluginHint:        testGitHubPluginHint,
+				GitHubSuggestPort:       "8080",
+			},
+			wantErr: "GITHUB_SUGGEST_OWNERS is required when GITHUB_SUGGEST_PORT is set",
+		},
+		{
+			name: "suggest_host_without_token",
+			cfg: &PluginConfig{
+				GitHubAppID:             testGitHubAppID,
+				GitHubAppInstallationID: testGitHubAppInstallationID,
+				GitHubAppPrivateKeyPEM:  testPrivateKeyString,
+				GitHubPluginDisplayName: testGitHubPluginDisplayName,
+				GitHubPluginHint:        testGitHubPluginHint,
+				GitHubSuggestPort:       "8080",
+				GitHubSuggestHost:       "0.0.0.0",
+				GitHubSuggestOwners:     []string{"my-org"},
+			},
+			wantErr: `GITHUB_SUGGEST_TOKEN is required when GITHUB_SUGGEST_HOST is not a loopback address, got "0.0.0.0"`,
+		},
+		{
+			name: "suggest_host_with_token",
+			cfg: &PluginConfig{
+				GitHubAppID:             testGitHubAppID,
+				GitHubAppInstallationID: testGitHubAppInstallationID,
+				GitHubAppPrivateKeyPEM:  testPrivateKeyString,
+				GitHubPluginDisplayName: testGitHubPluginDisplayName,
+				GitHubPluginHint:        testGitHubPluginHint,
+				GitHubSuggestPort:       "8080",
+				GitHubSuggestHost:       "0.0.0.0",
+				GitHubSuggestToken:      "my-secret",
+				GitHubSuggestOwners:     []string{"my-org"},
+			},
+		},
+		{
+			name: "negative_suggest_rate_limit",
+			cfg: &PluginConfig{
+				GitHubAppID:             testGitHubAppID,
+				GitHubAppInstallationID: testGitHubAppInstallationID,
+				GitHubAppPrivateKeyPEM:  testPrivateKeyString,
+				GitHubPluginDisplayName: testGitHubPluginDisplayName,
+				GitHubPluginHint:        testGitHubPluginHint,
+				GitHubSuggestRateLimit:  -1,
+			},
+			wantErr: "GITHUB_SUGGEST_RATE_LIMIT must be positive, got -1",
+		},
+		{
+			name: "negative_suggest_global_rate_limit",
+			cfg: &PluginConfig{
+				GitHubAppID:                  testGitHubAppID,
+				GitHubAppInstallationID:      testGitHubAppInstallationID,
+				GitHubAppPrivateKeyPEM:       testPrivateKeyString,
+				GitHubPluginDisplayName:      testGitHubPluginDisplayName,
+				GitHubPluginHint:             testGitHubPluginHint,
+				GitHubSuggestGlobalRateLimit: -1,
+			},
+			wantErr: "GITHUB_SUGGEST_GLOBAL_RATE_LIMIT must be positive, got -1",
+		},
+		{
+			name: "negative_circuit_breaker_threshold",
+			cfg: &PluginConfig{
+				GitHubAppID:                   testGitHubAppID,
+				GitHubAppInstallationID:       testGitHubAppInstallationID,
+				GitHubAppPrivateKeyPEM:        testPrivateKeyString,
+				GitHubPluginDisplayName:       testGitHubPluginDisplayName,
+				GitHubPluginHint:              testGitHubPluginHint,
+				GitHubCircuitBreakerThreshold: -1,
+			},
+			wantErr: "GITHUB_CIRCUIT_BREAKER_THRESHOLD must be positive, got -1",
+		},
+		{
+			name: "negative_circuit_breaker_cooldown",
+			cfg: &PluginConfig{
+				GitHubAppID:                  testGitHubAppID,
+				GitHubAppInstallationID:      testGitHubAppInstallationID,
+				GitHubAppPrivateKeyPEM:       testPrivateKeyString,
+				GitHubPluginDisplayName:      testGitHubPluginDisplayName,
+				GitHubPluginHint:             testGitHubPluginHint,
+				GitHubCircuitBreakerCooldown: -time.Second,
+			},
+			wantErr: "GITHUB_CIRCUIT_BREAKER_COOLDOWN must be positive, got -1s",
+		},
 	}
 
 	for _, tc := range cases {
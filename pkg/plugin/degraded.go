@@ -0,0 +1,214 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+
+	jvspb "github.com/abcxyz/jvs/apis/v0"
+	"github.com/abcxyz/pkg/cache"
+	"github.com/abcxyz/pkg/logging"
+)
+
+const (
+	// defaultDegradedWindow is how long successfully validated references are
+	// accepted in degraded mode by default.
+	defaultDegradedWindow = time.Hour
+
+	// Allowed values of the respAnnotationKeyValidationDegradedSource
+	// annotation.
+	degradedSourceCache     = "cache"
+	degradedSourceAllowlist = "allowlist"
+)
+
+const (
+	// respAnnotationKeyValidationDegraded is "true" when a reference was
+	// accepted without GitHub because it was unreachable.
+	respAnnotationKeyValidationDegraded = "github_validation_degraded"
+	// respAnnotationKeyValidationDegradedSource is why the reference was
+	// accepted in degraded mode, "cache" or "allowlist".
+	respAnnotationKeyValidationDegradedSource = "github_validation_degraded_source"
+	// respAnnotationKeyValidatedAt is when a reference accepted from the cache
+	// was last validated against GitHub.
+	respAnnotationKeyValidatedAt = "github_validated_at"
+)
+
+// degradedEntry is a successfully validated reference.
+type degradedEntry struct {
+	annotation  map[string]string
+	validatedAt time.Time
+}
+
+// degradedFallback accepts references while GitHub is unreachable, when they
+// were validated successfully within the window or are in the allowlist.
+type degradedFallback struct {
+	window    time.Duration
+	allowlist []string
+	// validated are the successfully validated references, by URL.
+	validated *cache.Cache[*degradedEntry]
+	// now returns the current time, it is overridden in tests.
+	now func() time.Time
+}
+
+// newDegradedFallback creates the degraded mode fallback of the policy, nil
+// when degraded mode is disabled.
+func newDegradedFallback(policy *Policy) *degradedFallback {
+	if !policy.DegradedMode || policy.DegradedWindow <= 0 {
+		return nil
+	}
+	return &degradedFallback{
+		window:    policy.DegradedWindow,
+		allowlist: policy.DegradedAllowlist,
+		validated: cache.New[*degradedEntry](policy.DegradedWindow),
+		now:       time.Now,
+	}
+}
+
+// record remembers the successfully validated reference.
+func (d *degradedFallback) record(ref string, result *referenceResult) {
+	d.validated.Set(ref, &degradedEntry{
+		annotation:  maps.Clone(result.annotation),
+		validatedAt: d.now(),
+	})
+}
+
+// lookup returns the result of the reference in degraded mode, if it was
+// validated successfully within the window or is in the allowlist.
+func (d *degradedFallback) lookup(ref string) (*referenceResult, bool) {
+	if e, ok := d.validated.Lookup(ref); ok && d.now().Sub(e.validatedAt) <= d.window {
+		annotation := maps.Clone(e.annotation)
+		annotation[respAnnotationKeyValidationDegraded] = "true"
+		annotation[respAnnotationKeyValidationDegradedSource] = degradedSourceCache
+		annotation[respAnnotationKeyValidatedAt] = e.validatedAt.UTC().Format(time.RFC3339)
+		return &referenceResult{annotation: annotation, degraded: true}, true
+	}
+	if slices.Contains(d.allowlist, ref) {
+		return &referenceResult{annotation: map[string]string{
+			respAnnotationKeyValidationDegraded:       "true",
+			respAnnotationKeyValidationDegradedSource: degradedSourceAllowlist,
+		}, degraded: true}, true
+	}
+	return nil, false
+}
+
+// matchReferenceWithFallback validates the reference like matchReference, and
+// falls back to the degraded mode of the category when GitHub is unreachable.
+// References accepted in degraded mode are not written back to.
+func (g *GitHubPlugin) matchReferenceWithFallback(ctx context.Context, category *pluginCategory, j *jvspb.Justification, ref string) (*referenceResult, error) {
+	result, err := g.matchReference(ctx, category, j, ref)
+	if category.degraded == nil {
+		return result, err
+	}
+	if err == nil {
+		category.degraded.record(ref, result)
+		return result, nil
+	}
+	if !isGitHubUnavailable(err) {
+		return nil, err
+	}
+
+	result, ok := category.degraded.lookup(ref)
+	if !ok {
+		return nil, err
+	}
+	logging.FromContext(ctx).WarnContext(ctx, "github is unreachable, reference accepted in degraded mode",
+		"category", j.GetCategory(),
+		"reference", ref,
+		"source", result.annotation[respAnnotationKeyValidationDegradedSource],
+		"error", err)
+	return result, nil
+}
+
+// tokenServerError is the error of a 5xx response of GitHub to a call of
+// githubauth, which doesn't expose the status of failed token exchanges.
+type tokenServerError struct {
+	statusCode int
+}
+
+func (e *tokenServerError) Error() string {
+	return fmt.Sprintf("github responded with status %d", e.statusCode)
+}
+
+// TokenStatusTransport returns a transport for the HTTP client of githubauth,
+// failing the calls GitHub responds to with a 5xx status with a
+// tokenServerError, so that they are recognized as GitHub being unavailable.
+func TokenStatusTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &tokenStatusTransport{base: base}
+}
+
+// tokenStatusTransport is the http.RoundTripper of TokenStatusTransport.
+type tokenStatusTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *tokenStatusTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Want passthrough
+	}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		resp.Body.Close()
+		// The http.Client wraps the error with the request.
+		return nil, &tokenServerError{statusCode: resp.StatusCode}
+	}
+	return resp, nil
+}
+
+// isGitHubUnavailable reports whether err is a transport error or a 5xx
+// response of GitHub, including of the token exchange through
+// TokenStatusTransport, rather than a rejection of the reference.
+func isGitHubUnavailable(err error) bool {
+	if err == nil || errors.Is(err, errInvalidJustification) {
+		return false
+	}
+	var respErr *github.ErrorResponse
+	if errors.As(err, &respErr) {
+		return respErr.Response != nil && respErr.Response.StatusCode >= http.StatusInternalServerError
+	}
+	var tokenErr *tokenServerError
+	if errors.As(err, &tokenErr) {
+		return true
+	}
+	// Requests which could not be sent or answered fail with a url.Error, other
+	// than malformed URLs.
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) && urlErr.Op != "parse"
+}
+
+// validateDegradedAllowlist validates the allowlisted references are GitHub
+// URLs.
+func validateDegradedAllowlist(allowlist []string) error {
+	var rErr error
+	for _, ref := range allowlist {
+		if u, err := url.Parse(ref); err != nil || u.Scheme != "https" || u.Host != "github.com" || strings.Trim(u.Path, "/") == "" {
+			rErr = errors.Join(rErr, fmt.Errorf("GITHUB_DEGRADED_ALLOWLIST reference %q must be a https://github.com/ URL", ref))
+		}
+	}
+	return rErr
+}
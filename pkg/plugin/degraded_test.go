@@ -0,0 +1,335 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v55/github"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	jvspb "github.com/abcxyz/jvs/apis/v0"
+	"github.com/abcxyz/pkg/cache"
+	"github.com/abcxyz/pkg/testutil"
+)
+
+// testGitHubErrorResponse returns the error of a GitHub response with the
+// status code.
+func testGitHubErrorResponse(code int) error {
+	return fmt.Errorf("failed to get issue info: %w", &github.ErrorResponse{
+		Response: &http.Response{
+			StatusCode: code,
+			Request:    &http.Request{Method: http.MethodGet, URL: &url.URL{Scheme: "https", Host: "api.github.com"}},
+		},
+		Message: http.StatusText(code),
+	})
+}
+
+func TestIsGitHubUnavailable(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "server_error",
+			err:  testGitHubErrorResponse(http.StatusBadGateway),
+			want: true,
+		},
+		{
+			name: "not_found",
+			err:  testGitHubErrorResponse(http.StatusNotFound),
+		},
+		{
+			name: "transport_error",
+			err:  fmt.Errorf("failed to get issue info: %w", &url.Error{Op: "Get", URL: "https://api.github.com", Err: fmt.Errorf("connection refused")}),
+			want: true,
+		},
+		{
+			name: "token_server_error",
+			err:  fmt.Errorf("failed to get access token: %w", &url.Error{Op: "Post", URL: "https://api.github.com", Err: &tokenServerError{statusCode: http.StatusServiceUnavailable}}),
+			want: true,
+		},
+		{
+			name: "token_client_error",
+			err:  fmt.Errorf("failed to get access token: %w", fmt.Errorf("invalid http response status (expected 403 to be 201): forbidden")),
+		},
+		{
+			name: "malformed_url",
+			err:  &url.Error{Op: "parse", URL: "::", Err: fmt.Errorf("missing protocol scheme")},
+		},
+		{
+			name: "invalid_justification",
+			err:  invalidf(ReasonIssueClosed, "issue is in state: closed"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isGitHubUnavailable(tc.err); got != tc.want {
+				t.Errorf("isGitHubUnavailable(%v) got %t, want %t", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateIssue_Unreachable(t *testing.T) {
+	t.Parallel()
+
+	// A closed server fails the request without a response.
+	svr := httptest.NewServer(http.NotFoundHandler())
+	svr.Close()
+	c := github.NewClient(nil)
+	baseURL, err := url.Parse(svr.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.BaseURL = baseURL
+
+	_, err = validateIssue(t.Context(), c, &pluginGitHubIssue{Owner: "test-owner", RepoName: "test-repo", IssueNumber: 1})
+	if err == nil {
+		t.Fatal("validateIssue() got no error")
+	}
+	if !isGitHubUnavailable(err) {
+		t.Errorf("validateIssue() got error %v, want GitHub unavailable", err)
+	}
+}
+
+// TestMatchIssue_TokenServerUnavailable exchanges tokens with githubauth, like
+// in production, through TokenStatusTransport.
+func TestMatchIssue_TokenServerUnavailable(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name            string
+		tokenStatusCode int
+		want            bool
+		wantErr         string
+	}{
+		{
+			name:            "service_unavailable",
+			tokenStatusCode: http.StatusServiceUnavailable,
+			want:            true,
+			wantErr:         "github responded with status 503",
+		},
+		{
+			name:            "internal_server_error",
+			tokenStatusCode: http.StatusInternalServerError,
+			want:            true,
+			wantErr:         "github responded with status 500",
+		},
+		{
+			name:            "forbidden",
+			tokenStatusCode: http.StatusForbidden,
+			wantErr:         "invalid http response status (expected 403 to be 201)",
+		},
+		{
+			name:            "unauthorized",
+			tokenStatusCode: http.StatusUnauthorized,
+			wantErr:         "invalid http response status (expected 401 to be 201)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			installation := testGitHubInstallation(t, tc.tokenStatusCode)
+			v := NewValidator(github.NewClient(nil), installation, &Policy{})
+			_, err := v.MatchIssue(t.Context(), testGitHubIssueURL)
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if got := isGitHubUnavailable(err); got != tc.want {
+				t.Errorf("isGitHubUnavailable(%v) got %t, want %t", err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidate_DegradedMode(t *testing.T) {
+	t.Parallel()
+
+	const allowlistedURL = "https://github.com/test-owner/runbooks/issues/7"
+	validatedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	unavailable := testGitHubErrorResponse(http.StatusServiceUnavailable)
+
+	validatedAnnotation := map[string]string{
+		respAnnotationKeyIssueURL:                 testGitHubIssueURL,
+		respAnnotationKeyIssueOwner:               "test-owner",
+		respAnnotationKeyIssueRepo:                "test-repo",
+		respAnnotationKeyIssueNumber:              "1",
+		respAnnotationKeyIssueSnapshotHash:        "",
+		respAnnotationKeyValidationDegraded:       "true",
+		respAnnotationKeyValidationDegradedSource: degradedSourceCache,
+		respAnnotationKeyValidatedAt:              "2026-10-01T12:00:00Z",
+	}
+
+	cases := []struct {
+		name      string
+		validated bool
+		elapsed   time.Duration
+		value     string
+		err       error
+		wantResq  *jvspb.ValidateJustificationResponse
+		wantCode  codes.Code
+	}{
+		{
+			name:      "recently_validated",
+			validated: true,
+			elapsed:   30 * time.Minute,
+			value:     testGitHubIssueURL,
+			err:       unavailable,
+			wantResq: &jvspb.ValidateJustificationResponse{
+				Valid:      true,
+				Annotation: validatedAnnotation,
+			},
+		},
+		{
+			name:      "validated_outside_window",
+			validated: true,
+			elapsed:   2 * time.Hour,
+			value:     testGitHubIssueURL,
+			err:       unavailable,
+			wantCode:  codes.Internal,
+		},
+		{
+			name:     "never_validated",
+			value:    testGitHubIssueURL,
+			err:      unavailable,
+			wantCode: codes.Internal,
+		},
+		{
+			name:  "allowlisted",
+			value: allowlistedURL,
+			err:   unavailable,
+			wantResq: &jvspb.ValidateJustificationResponse{
+				Valid: true,
+				Annotation: map[string]string{
+					respAnnotationKeyValidationDegraded:       "true",
+					respAnnotationKeyValidationDegradedSource: degradedSourceAllowlist,
+				},
+			},
+		},
+		{
+			name:      "not_found",
+			validated: true,
+			value:     testGitHubIssueURL,
+			err:       invalidf(ReasonReferenceNotFound, "issue not found"),
+			wantResq:  generateInvalidErrResq(ReasonReferenceNotFound, "invalid justification: issue not found"),
+		},
+		{
+			name:      "client_error",
+			validated: true,
+			value:     testGitHubIssueURL,
+			err:       testGitHubErrorResponse(http.StatusForbidden),
+			wantCode:  codes.Internal,
+		},
+		{
+			name:      "multiple_references",
+			validated: true,
+			value:     testGitHubIssueURL + "," + allowlistedURL,
+			err:       unavailable,
+			wantResq: &jvspb.ValidateJustificationResponse{
+				Valid: true,
+				Annotation: map[string]string{
+					"github_ref_0_url":                        testGitHubIssueURL,
+					"github_ref_0_issue_url":                  testGitHubIssueURL,
+					"github_ref_0_issue_owner":                "test-owner",
+					"github_ref_0_issue_repo":                 "test-repo",
+					"github_ref_0_issue_number":               "1",
+					"github_ref_0_issue_snapshot_hash":        "",
+					"github_ref_0_validation_degraded":        "true",
+					"github_ref_0_validation_degraded_source": degradedSourceCache,
+					"github_ref_0_validated_at":               "2026-10-01T12:00:00Z",
+					"github_ref_1_url":                        allowlistedURL,
+					"github_ref_1_validation_degraded":        "true",
+					"github_ref_1_validation_degraded_source": degradedSourceAllowlist,
+					respAnnotationKeyValidationDegraded:       "true",
+					respAnnotationKeyReferences:               testGitHubIssueURL + "," + allowlistedURL,
+					respAnnotationKeyReferenceMode:            referenceModeAll,
+					respAnnotationKeyReferenceCount:           "2",
+					respAnnotationKeyReferenceValidCount:      "2",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			now := validatedAt
+			degraded := &degradedFallback{
+				window:    time.Hour,
+				allowlist: []string{allowlistedURL},
+				validated: cache.New[*degradedEntry](time.Hour),
+				now:       func() time.Time { return now },
+			}
+			t.Cleanup(degraded.validated.Stop)
+
+			matcher := &testReferenceMatcher{
+				rPluginGitHubIssue: &pluginGitHubIssue{Owner: "test-owner", RepoName: "test-repo", IssueNumber: 1},
+			}
+			p := &GitHubPlugin{
+				categories: map[string]*pluginCategory{
+					githubCategory: {validator: matcher, degraded: degraded},
+				},
+			}
+			req := &jvspb.ValidateJustificationRequest{
+				Justification: &jvspb.Justification{
+					Category: githubCategory,
+					Value:    tc.value,
+				},
+			}
+
+			if tc.validated {
+				if _, err := p.Validate(t.Context(), &jvspb.ValidateJustificationRequest{
+					Justification: &jvspb.Justification{
+						Category: githubCategory,
+						Value:    testGitHubIssueURL,
+					},
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			now = now.Add(tc.elapsed)
+			matcher.rErr = tc.err
+
+			gotResq, err := p.Validate(t.Context(), req)
+			if got := status.Code(err); got != tc.wantCode {
+				t.Errorf("Validate() got code %s, want %s, error: %v", got, tc.wantCode, err)
+			}
+			if diff := cmp.Diff(tc.wantResq, gotResq, cmpopts.IgnoreUnexported(jvspb.ValidateJustificationResponse{})); diff != "" {
+				t.Errorf("Validate() unexpected diff (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
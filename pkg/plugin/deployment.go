@@ -0,0 +1,342 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/abcxyz/pkg/githubauth"
+)
+
+const (
+	// deploymentURLPatternRegExp matches the deployments page of an
+	// environment, and the deployment ID format of the plugin, which GitHub
+	// doesn't serve pages for.
+	deploymentURLPatternRegExp = `^https:\/\/github.com\/([a-zA-Z0-9-]*)\/[a-zA-Z0-9-]*\/deployments\/[^\/?#]+$`
+
+	// deploymentEnvironmentQueryParam names the environment of the deployment
+	// when a workflow run URL is used as deployment reference.
+	deploymentEnvironmentQueryParam = "environment"
+
+	// deploymentStateWaiting is the state of deployments waiting for the
+	// environment's required reviewers.
+	deploymentStateWaiting = "waiting"
+	// deploymentStatePending is the state of deployments without any status.
+	deploymentStatePending = "pending"
+)
+
+const (
+	// maxRunDeployments bounds the deployments of the commit of a workflow run
+	// searched for the deployment of the run, and maxDeploymentStatuses the
+	// statuses of each.
+	maxRunDeployments     = 10
+	maxDeploymentStatuses = 100
+)
+
+// deploymentIDRegExp matches the deployment IDs of deployment URLs.
+var deploymentIDRegExp = regexp.MustCompile(`^[0-9]+$`)
+
+// deploymentStates are the known deployment states.
+var deploymentStates = []string{
+	"error", "failure", "inactive", "in_progress", "queued", "success",
+	deploymentStateWaiting, deploymentStatePending,
+}
+
+// defaultDeploymentAllowedStates are the deployment states accepted by default,
+// which are those of deployments pending approval or approved and not
+// finished yet.
+var defaultDeploymentAllowedStates = []string{deploymentStateWaiting, deploymentStatePending, "queued", "in_progress"}
+
+// pluginGitHubDeployment contains the required attribute parsed from the
+// deployment reference, and the attributes of the deployment used in
+// annotations.
+type pluginGitHubDeployment struct {
+	Owner    string
+	RepoName string
+
+	// RunID is set when the deployment is referenced by workflow run and
+	// environment, DeploymentID when it is referenced by ID, and only
+	// Environment when it is referenced by the deployments page of the
+	// environment. DeploymentID is not set for deployments waiting for
+	// approval of a workflow run.
+	RunID        int64
+	DeploymentID int64
+	Environment  string
+
+	State string
+}
+
+// pendingDeployment is a deployment of a workflow run waiting for approval.
+type pendingDeployment struct {
+	Environment struct {
+		Name string `json:"name"`
+	} `json:"environment"`
+}
+
+// MatchDeployment parses the deployment reference, which is the deployments
+// page of an environment, a workflow run URL with an environment query
+// parameter or a deployment URL, and validates the deployment against the
+// policy.
+func (v *Validator) MatchDeployment(ctx context.Context, deploymentURL string) (*pluginGitHubDeployment, error) {
+	info, err := parseDeploymentInfoFromURL(deploymentURL)
+	if err != nil {
+		return nil, invalidf(ReasonReferenceMalformed, "failed to parse deployment reference: %w", err)
+	}
+
+	t, err := v.getDeploymentAccessToken(ctx, info.RepoName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+	c := v.client.WithAuthToken(t)
+
+	var deployment *github.Deployment
+	switch {
+	case info.RunID != 0:
+		deployment, err = findRunDeployment(ctx, c, info)
+	case info.DeploymentID != 0:
+		deployment, err = getDeployment(ctx, c, info)
+	default:
+		deployment, err = getLatestDeployment(ctx, c, info)
+	}
+	if err != nil {
+		return info, err
+	}
+
+	if deployment != nil {
+		info.DeploymentID = deployment.GetID()
+		info.Environment = deployment.GetEnvironment()
+
+		state, err := deploymentState(ctx, c, info)
+		if err != nil {
+			return info, err
+		}
+		info.State = state
+	}
+
+	if allowed := v.policy.DeploymentEnvironments; len(allowed) > 0 &&
+		!slices.ContainsFunc(allowed, func(e string) bool { return strings.EqualFold(e, info.Environment) }) {
+		if err := v.enforce(ctx, violationf(ruleDeploymentEnvironments, "deployment environment %q is not one of %q", info.Environment, allowed)); err != nil {
+			return info, err
+		}
+	}
+
+	allowed := v.policy.DeploymentAllowedStates
+	if len(allowed) == 0 {
+		allowed = defaultDeploymentAllowedStates
+	}
+	if !slices.Contains(allowed, info.State) {
+		if err := v.enforce(ctx, violationf(ruleDeploymentAllowedStates, "deployment is in state: %s, expected one of %q", info.State, allowed)); err != nil {
+			return info, err
+		}
+	}
+
+	return info, nil
+}
+
+// getDeployment gets the deployment referenced by ID.
+func getDeployment(ctx context.Context, c *github.Client, info *pluginGitHubDeployment) (*github.Deployment, error) {
+	d, resp, err := c.Repositories.GetDeployment(ctx, info.Owner, info.RepoName, info.DeploymentID)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, invalidf(ReasonReferenceNotFound, "deployment not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get deployment info: %w", err)
+	}
+	return d, nil
+}
+
+// getLatestDeployment gets the latest deployment to the environment, which is
+// the one shown first on its deployments page.
+func getLatestDeployment(ctx context.Context, c *github.Client, info *pluginGitHubDeployment) (*github.Deployment, error) {
+	// Deployments are listed newest first.
+	deployments, _, err := c.Repositories.ListDeployments(ctx, info.Owner, info.RepoName, &github.DeploymentsListOptions{
+		Environment: info.Environment,
+		ListOptions: github.ListOptions{PerPage: 1},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	if len(deployments) == 0 {
+		return nil, invalidf(ReasonReferenceNotFound, "environment %q has no deployment", info.Environment)
+	}
+	return deployments[0], nil
+}
+
+// findRunDeployment finds the deployment of the workflow run to the
+// environment. It returns nil and sets the waiting state when the run is
+// waiting for approval to deploy to the environment, as the deployment
+// doesn't exist yet in that case.
+func findRunDeployment(ctx context.Context, c *github.Client, info *pluginGitHubDeployment) (*github.Deployment, error) {
+	run, resp, err := c.Actions.GetWorkflowRunByID(ctx, info.Owner, info.RepoName, info.RunID)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, invalidf(ReasonReferenceNotFound, "workflow run not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get workflow run info: %w", err)
+	}
+
+	// The client library doesn't support pending deployments.
+	req, err := c.NewRequest(http.MethodGet,
+		fmt.Sprintf("repos/%s/%s/actions/runs/%d/pending_deployments", info.Owner, info.RepoName, info.RunID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pending deployments request: %w", err)
+	}
+	var pending []*pendingDeployment
+	if _, err := c.Do(ctx, req, &pending); err != nil {
+		return nil, fmt.Errorf("failed to get pending deployments: %w", err)
+	}
+	for _, p := range pending {
+		if strings.EqualFold(p.Environment.Name, info.Environment) {
+			info.Environment = p.Environment.Name
+			info.State = deploymentStateWaiting
+			return nil, nil
+		}
+	}
+
+	// Deployments are listed newest first. Other runs of the same commit, like
+	// re-runs, may deploy to the environment too, so the deployment is matched
+	// by the run linked from its statuses.
+	deployments, _, err := c.Repositories.ListDeployments(ctx, info.Owner, info.RepoName, &github.DeploymentsListOptions{
+		SHA:         run.GetHeadSHA(),
+		Environment: info.Environment,
+		ListOptions: github.ListOptions{PerPage: maxRunDeployments},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, d := range deployments {
+		statuses, _, err := c.Repositories.ListDeploymentStatuses(ctx, info.Owner, info.RepoName, d.GetID(),
+			&github.ListOptions{PerPage: maxDeploymentStatuses})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list deployment statuses: %w", err)
+		}
+		for _, s := range statuses {
+			if isWorkflowRunURL(s.GetLogURL(), info.RunID) || isWorkflowRunURL(s.GetTargetURL(), info.RunID) {
+				return d, nil
+			}
+		}
+	}
+	return nil, invalidf(ReasonReferenceNotFound, "workflow run has no deployment to environment %q", info.Environment)
+}
+
+// isWorkflowRunURL reports whether the URL links to the workflow run or one of
+// its jobs or attempts.
+func isWorkflowRunURL(s string, runID int64) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	runPath := fmt.Sprintf("/actions/runs/%d", runID)
+	return strings.HasSuffix(u.Path, runPath) || strings.Contains(u.Path, runPath+"/")
+}
+
+// deploymentState returns the state of the latest deployment status, or
+// pending if the deployment has no status yet.
+func deploymentState(ctx context.Context, c *github.Client, info *pluginGitHubDeployment) (string, error) {
+	// Deployment statuses are listed newest first.
+	statuses, _, err := c.Repositories.ListDeploymentStatuses(ctx, info.Owner, info.RepoName, info.DeploymentID,
+		&github.ListOptions{PerPage: 1})
+	if err != nil {
+		return "", fmt.Errorf("failed to list deployment statuses: %w", err)
+	}
+	if len(statuses) == 0 {
+		return deploymentStatePending, nil
+	}
+	return statuses[0].GetState(), nil
+}
+
+// getDeploymentAccessToken gets an access token with deployment and actions
+// read permission to the repo which contains the deployment.
+func (v *Validator) getDeploymentAccessToken(ctx context.Context, repoName string) (string, error) {
+	tr := &githubauth.TokenRequest{
+		Repositories: []string{repoName},
+		Permissions: map[string]string{
+			"actions":     "read",
+			"deployments": "read",
+		},
+	}
+
+	resp, err := v.githubInstallation.AccessToken(ctx, tr)
+	if err != nil {
+		return "", fmt.Errorf("failed to get access token: %w", err)
+	}
+	return resp, nil
+}
+
+// isDeploymentReference reports whether the workflow run URL references a
+// deployment of the run through the environment query parameter.
+func isDeploymentReference(u *url.URL) bool {
+	return u.Query().Get(deploymentEnvironmentQueryParam) != ""
+}
+
+// parseDeploymentInfoFromURL parses pluginGitHubDeployment from the deployments
+// page of an environment, a deployment URL, or a workflow run URL with an
+// environment query parameter. Numeric environment names are parsed as
+// deployment IDs.
+func parseDeploymentInfoFromURL(deploymentURL string) (*pluginGitHubDeployment, error) {
+	u, err := url.Parse(deploymentURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse provided deployment url: %w", err)
+	}
+
+	if environment := u.Query().Get(deploymentEnvironmentQueryParam); environment != "" {
+		base := (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}).String()
+		run, err := parseWorkflowRunInfoFromURL(base)
+		if err != nil {
+			return nil, err
+		}
+		return &pluginGitHubDeployment{
+			Owner:       run.Owner,
+			RepoName:    run.RepoName,
+			RunID:       run.RunID,
+			Environment: environment,
+		}, nil
+	}
+
+	if match, _ := regexp.MatchString(deploymentURLPatternRegExp, deploymentURL); !match {
+		return nil, fmt.Errorf("invalid deployment url, %s", referenceHint(deploymentURL, referenceKindDeployment))
+	}
+
+	// The environment name is escaped, and may contain escaped slashes.
+	arr := strings.Split(u.EscapedPath(), "/")
+	// len(arr) is not checked here as regexp.MatchString already covers this.
+	info := &pluginGitHubDeployment{
+		Owner:    arr[1],
+		RepoName: arr[2],
+	}
+	if !deploymentIDRegExp.MatchString(arr[4]) {
+		environment, err := url.PathUnescape(arr[4])
+		if err != nil {
+			return nil, fmt.Errorf("failed to unescape environment %s: %w", arr[4], err)
+		}
+		info.Environment = environment
+		return info, nil
+	}
+	deploymentID, err := strconv.ParseInt(arr[4], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert deploymentID %s to int: %w", arr[4], err)
+	}
+	info.DeploymentID = deploymentID
+	return info, nil
+}
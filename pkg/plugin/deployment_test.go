@@ -0,0 +1,288 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v55/github"
+
+	"github.com/abcxyz/pkg/testutil"
+)
+
+// testHandleDeployments returns a fake http func that serves deployment 10 of
+// the test repo with the given statuses, workflow run 123 with the given
+// pending deployments, and deployments listed by run head SHA. Deployment 11
+// belongs to another run of the same commit.
+func testHandleDeployments(tb testing.TB, statuses, pending, deployments string) func(w http.ResponseWriter, r *http.Request) {
+	tb.Helper()
+	repoPath := fmt.Sprintf("%s/%s/%s", issueRESTAPIPathPrefix, testIssueOwner, testIssueRepoName)
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case repoPath + "/deployments/10":
+			fmt.Fprint(w, `{"id": 10, "environment": "production"}`)
+		case repoPath + "/deployments/10/statuses":
+			fmt.Fprint(w, statuses)
+		case repoPath + "/deployments/11/statuses":
+			fmt.Fprint(w, `[{"state": "success", "log_url": "https://github.com/test-owner/test-repo/actions/runs/999/job/1"}]`)
+		case repoPath + "/deployments/404":
+			http.Error(w, "deployment not found", http.StatusNotFound)
+		case repoPath + "/actions/runs/123":
+			fmt.Fprint(w, `{"id": 123, "head_sha": "abc123"}`)
+		case repoPath + "/actions/runs/123/pending_deployments":
+			fmt.Fprint(w, pending)
+		case repoPath + "/deployments":
+			// Deployments are listed by environment for environment pages,
+			// and by run head SHA for workflow runs.
+			if r.URL.Query().Get("sha") == "" {
+				if got, want := r.URL.Query().Get("per_page"), "1"; got != want {
+					tb.Errorf("deployments per_page got %q, want %q", got, want)
+				}
+				if r.URL.Query().Get("environment") == "Production EU" {
+					fmt.Fprint(w, `[{"id": 10, "environment": "Production EU"}]`)
+					return
+				}
+				fmt.Fprint(w, `[]`)
+				return
+			}
+			if got, want := r.URL.Query().Get("sha"), "abc123"; got != want {
+				tb.Errorf("deployments sha got %q, want %q", got, want)
+			}
+			fmt.Fprint(w, deployments)
+		default:
+			http.Error(w, "injected server error", http.StatusInternalServerError)
+		}
+	}
+}
+
+func TestMatchDeployment(t *testing.T) {
+	t.Parallel()
+
+	const (
+		deploymentURL = "https://github.com/test-owner/test-repo/deployments/10"
+		runURL        = "https://github.com/test-owner/test-repo/actions/runs/123?environment=production"
+	)
+
+	cases := []struct {
+		name          string
+		deploymentURL string
+		policy        *Policy
+		statuses      string
+		pending       string
+		deployments   string
+		want          *pluginGitHubDeployment
+		wantErr       string
+		wantReason    Reason
+	}{
+		{
+			name:          "deployment_in_progress",
+			deploymentURL: deploymentURL,
+			policy:        &Policy{},
+			statuses:      `[{"state": "in_progress"}, {"state": "queued"}]`,
+			want: &pluginGitHubDeployment{
+				Owner:        testIssueOwner,
+				RepoName:     testIssueRepoName,
+				DeploymentID: 10,
+				Environment:  "production",
+				State:        "in_progress",
+			},
+		},
+		{
+			name:          "deployment_without_status",
+			deploymentURL: deploymentURL,
+			policy:        &Policy{},
+			statuses:      `[]`,
+			want: &pluginGitHubDeployment{
+				Owner:        testIssueOwner,
+				RepoName:     testIssueRepoName,
+				DeploymentID: 10,
+				Environment:  "production",
+				State:        "pending",
+			},
+		},
+		{
+			name:          "deployment_finished",
+			deploymentURL: deploymentURL,
+			policy:        &Policy{},
+			statuses:      `[{"state": "success"}]`,
+			want: &pluginGitHubDeployment{
+				Owner:        testIssueOwner,
+				RepoName:     testIssueRepoName,
+				DeploymentID: 10,
+				Environment:  "production",
+				State:        "success",
+			},
+			wantErr:    "deployment is in state: success",
+			wantReason: ReasonDeploymentNotAllowed,
+		},
+		{
+			name:          "deployment_finished_allowed",
+			deploymentURL: deploymentURL,
+			policy:        &Policy{DeploymentAllowedStates: []string{"success"}},
+			statuses:      `[{"state": "success"}]`,
+			want: &pluginGitHubDeployment{
+				Owner:        testIssueOwner,
+				RepoName:     testIssueRepoName,
+				DeploymentID: 10,
+				Environment:  "production",
+				State:        "success",
+			},
+		},
+		{
+			name:          "environment_not_allowed",
+			deploymentURL: deploymentURL,
+			policy:        &Policy{DeploymentEnvironments: []string{"staging"}},
+			statuses:      `[{"state": "in_progress"}]`,
+			want: &pluginGitHubDeployment{
+				Owner:        testIssueOwner,
+				RepoName:     testIssueRepoName,
+				DeploymentID: 10,
+				Environment:  "production",
+				State:        "in_progress",
+			},
+			wantErr:    `deployment environment "production" is not one of ["staging"]`,
+			wantReason: ReasonDeploymentNotAllowed,
+		},
+		{
+			name:          "deployment_not_found",
+			deploymentURL: "https://github.com/test-owner/test-repo/deployments/404",
+			policy:        &Policy{},
+			want: &pluginGitHubDeployment{
+				Owner:        testIssueOwner,
+				RepoName:     testIssueRepoName,
+				DeploymentID: 404,
+			},
+			wantErr:    "deployment not found",
+			wantReason: ReasonReferenceNotFound,
+		},
+		{
+			name:          "run_waiting_for_approval",
+			deploymentURL: runURL,
+			policy:        &Policy{},
+			pending:       `[{"environment": {"name": "Production"}}]`,
+			want: &pluginGitHubDeployment{
+				Owner:       testIssueOwner,
+				RepoName:    testIssueRepoName,
+				RunID:       123,
+				Environment: "Production",
+				State:       "waiting",
+			},
+		},
+		{
+			name:          "run_approved",
+			deploymentURL: runURL,
+			policy:        &Policy{},
+			pending:       `[]`,
+			deployments:   `[{"id": 11, "environment": "production"}, {"id": 10, "environment": "production"}]`,
+			statuses:      `[{"state": "queued", "log_url": "https://github.com/test-owner/test-repo/actions/runs/123/job/5"}]`,
+			want: &pluginGitHubDeployment{
+				Owner:        testIssueOwner,
+				RepoName:     testIssueRepoName,
+				RunID:        123,
+				DeploymentID: 10,
+				Environment:  "production",
+				State:        "queued",
+			},
+		},
+		{
+			name:          "run_without_deployment",
+			deploymentURL: runURL,
+			policy:        &Policy{},
+			pending:       `[]`,
+			deployments:   `[]`,
+			want: &pluginGitHubDeployment{
+				Owner:       testIssueOwner,
+				RepoName:    testIssueRepoName,
+				RunID:       123,
+				Environment: "production",
+			},
+			wantErr:    `workflow run has no deployment to environment "production"`,
+			wantReason: ReasonReferenceNotFound,
+		},
+		{
+			name:          "run_deployment_of_other_run",
+			deploymentURL: runURL,
+			policy:        &Policy{},
+			pending:       `[]`,
+			deployments:   `[{"id": 11, "environment": "production"}]`,
+			want: &pluginGitHubDeployment{
+				Owner:       testIssueOwner,
+				RepoName:    testIssueRepoName,
+				RunID:       123,
+				Environment: "production",
+			},
+			wantErr:    `workflow run has no deployment to environment "production"`,
+			wantReason: ReasonReferenceNotFound,
+		},
+		{
+			name:          "environment_page",
+			deploymentURL: "https://github.com/test-owner/test-repo/deployments/Production%20EU",
+			policy:        &Policy{DeploymentEnvironments: []string{"production eu"}},
+			statuses:      `[{"state": "in_progress"}]`,
+			want: &pluginGitHubDeployment{
+				Owner:        testIssueOwner,
+				RepoName:     testIssueRepoName,
+				DeploymentID: 10,
+				Environment:  "Production EU",
+				State:        "in_progress",
+			},
+		},
+		{
+			name:          "environment_page_without_deployment",
+			deploymentURL: "https://github.com/test-owner/test-repo/deployments/staging",
+			policy:        &Policy{},
+			want: &pluginGitHubDeployment{
+				Owner:       testIssueOwner,
+				RepoName:    testIssueRepoName,
+				Environment: "staging",
+			},
+			wantErr:    `environment "staging" has no deployment`,
+			wantReason: ReasonReferenceNotFound,
+		},
+		{
+			name:          "invalid_url",
+			deploymentURL: "https://github.com/test-owner/test-repo/deployments/production/activity",
+			policy:        &Policy{},
+			wantErr:       "invalid deployment url",
+			wantReason:    ReasonReferenceMalformed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			installation := testGitHubInstallation(t, http.StatusCreated)
+			hc := newTestServer(t, testHandleDeployments(t, tc.statuses, tc.pending, tc.deployments))
+
+			v := NewValidator(github.NewClient(hc), installation, tc.policy)
+			got, err := v.MatchDeployment(t.Context(), tc.deploymentURL)
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if got, want := testReason(err), tc.wantReason; got != want {
+				t.Errorf("reason of %v got %q, want %q", err, got, want)
+			}
+			if tc.wantErr == "" || tc.want != nil {
+				if diff := cmp.Diff(tc.want, got); diff != "" {
+					t.Errorf("MatchDeployment() unexpected diff (-want,+got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
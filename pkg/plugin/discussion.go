@@ -0,0 +1,184 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abcxyz/pkg/githubauth"
+)
+
+const (
+	discussionURLPatternRegExp = `^https:\/\/github.com\/([a-zA-Z0-9-]*)\/[a-zA-Z0-9-]*\/discussions\/[0-9]+$`
+
+	// discussionQuery fetches the discussion fields the policy is applied to.
+	discussionQuery = `query($owner: String!, $repo: String!, $number: Int!) {
+  repository(owner: $owner, name: $repo) {
+    discussion(number: $number) {
+      closed
+      locked
+      isAnswered
+      createdAt
+      category {
+        name
+      }
+    }
+  }
+}`
+)
+
+// pluginGitHubDiscussion contains the required attribute parsed from the
+// discussion URL, and the attributes of the discussion used in annotations.
+type pluginGitHubDiscussion struct {
+	Owner            string
+	RepoName         string
+	DiscussionNumber int
+
+	Category string
+	Answered bool
+}
+
+// discussionQueryResult is the response data of discussionQuery.
+type discussionQueryResult struct {
+	Repository *struct {
+		Discussion *struct {
+			Closed     bool      `json:"closed"`
+			Locked     bool      `json:"locked"`
+			IsAnswered bool      `json:"isAnswered"`
+			CreatedAt  time.Time `json:"createdAt"`
+			Category   struct {
+				Name string `json:"name"`
+			} `json:"category"`
+		} `json:"discussion"`
+	} `json:"repository"`
+}
+
+// MatchDiscussion parses discussion info from provided discussionURL and
+// validates the discussion against the policy.
+func (v *Validator) MatchDiscussion(ctx context.Context, discussionURL string) (*pluginGitHubDiscussion, error) {
+	info, err := parseDiscussionInfoFromURL(discussionURL)
+	if err != nil {
+		return nil, invalidf(ReasonReferenceMalformed, "failed to parse discussionURL: %w", err)
+	}
+
+	t, err := v.getDiscussionAccessToken(ctx, info.RepoName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+	c := v.client.WithAuthToken(t)
+
+	var result discussionQueryResult
+	if err := queryGraphQL(ctx, c, discussionQuery, map[string]any{
+		"owner":  info.Owner,
+		"repo":   info.RepoName,
+		"number": info.DiscussionNumber,
+	}, &result); err != nil {
+		if isGraphQLNotFound(err) {
+			return info, invalidf(ReasonReferenceNotFound, "discussion not found: %w", err)
+		}
+		return info, fmt.Errorf("failed to get discussion info: %w", err)
+	}
+	if result.Repository == nil || result.Repository.Discussion == nil {
+		return info, invalidf(ReasonReferenceNotFound, "discussion not found")
+	}
+	d := result.Repository.Discussion
+
+	info.Category = d.Category.Name
+	info.Answered = d.IsAnswered
+
+	if d.Closed {
+		return info, invalidf(ReasonDiscussionClosed, "discussion is closed, please make sure to use an open discussion")
+	}
+	if d.Locked && !v.policy.DiscussionAllowLocked {
+		if err := v.enforce(ctx, invalidf(ReasonDiscussionLocked, "discussion is locked")); err != nil {
+			return info, err
+		}
+	}
+	if allowed := v.policy.DiscussionCategories; len(allowed) > 0 &&
+		!slices.ContainsFunc(allowed, func(c string) bool { return strings.EqualFold(c, d.Category.Name) }) {
+		if err := v.enforce(ctx, violationf(ruleDiscussionCategories, "discussion category %q is not one of %q", d.Category.Name, allowed)); err != nil {
+			return info, err
+		}
+	}
+	switch v.policy.DiscussionAnswered {
+	case discussionAnsweredAnswered:
+		if !d.IsAnswered {
+			if err := v.enforce(ctx, violationf(ruleDiscussionAnswered, "discussion must be answered")); err != nil {
+				return info, err
+			}
+		}
+	case discussionAnsweredUnanswered:
+		if d.IsAnswered {
+			if err := v.enforce(ctx, violationf(ruleDiscussionAnswered, "discussion must not be answered")); err != nil {
+				return info, err
+			}
+		}
+	}
+	if maxAge := v.policy.DiscussionMaxAge; maxAge > 0 && time.Since(d.CreatedAt) > maxAge {
+		if err := v.enforce(ctx, violationf(ruleDiscussionMaxAge, "discussion was created more than %s ago", maxAge)); err != nil {
+			return info, err
+		}
+	}
+
+	return info, nil
+}
+
+// getDiscussionAccessToken gets an access token with discussion read
+// permission to the repo which contains the discussion.
+func (v *Validator) getDiscussionAccessToken(ctx context.Context, repoName string) (string, error) {
+	tr := &githubauth.TokenRequest{
+		Repositories: []string{repoName},
+		Permissions: map[string]string{
+			"discussions": "read",
+		},
+	}
+
+	resp, err := v.githubInstallation.AccessToken(ctx, tr)
+	if err != nil {
+		return "", fmt.Errorf("failed to get access token: %w", err)
+	}
+	return resp, nil
+}
+
+// parseDiscussionInfoFromURL parses pluginGitHubDiscussion from discussion URL.
+func parseDiscussionInfoFromURL(discussionURL string) (*pluginGitHubDiscussion, error) {
+	if match, _ := regexp.MatchString(discussionURLPatternRegExp, discussionURL); !match {
+		return nil, fmt.Errorf("invalid discussion url, %s", referenceHint(discussionURL, referenceKindDiscussion))
+	}
+	u, err := url.Parse(discussionURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse provided discussion url: %w", err)
+	}
+
+	arr := strings.Split(u.Path, "/")
+	// len(arr) is not checked here as regexp.MatchString already covers this.
+	discussionNumber, err := strconv.Atoi(arr[4])
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert discussionNumber %s to int: %w", arr[4], err)
+	}
+
+	return &pluginGitHubDiscussion{
+		Owner:            arr[1],
+		RepoName:         arr[2],
+		DiscussionNumber: discussionNumber,
+	}, nil
+}
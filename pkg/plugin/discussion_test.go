@@ -0,0 +1,204 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v55/github"
+
+	"github.com/abcxyz/pkg/testutil"
+)
+
+// testHandleGraphQL returns a fake http func that serves GraphQL requests with
+// the response returned by fn for the request variables.
+func testHandleGraphQL(tb testing.TB, fn func(vars map[string]any) string) func(w http.ResponseWriter, r *http.Request) {
+	tb.Helper()
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/graphql" {
+			http.Error(w, "injected server error", http.StatusInternalServerError)
+			return
+		}
+		var req graphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, fn(req.Variables))
+	}
+}
+
+func TestMatchDiscussion(t *testing.T) {
+	t.Parallel()
+
+	recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	old := time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339)
+
+	discussion := func(closed, locked, answered bool, createdAt, category string) string {
+		return fmt.Sprintf(`{"data": {"repository": {"discussion": {"closed": %t, "locked": %t, "isAnswered": %t, "createdAt": %q, "category": {"name": %q}}}}}`,
+			closed, locked, answered, createdAt, category)
+	}
+
+	wantInfo := func(category string, answered bool) *pluginGitHubDiscussion {
+		return &pluginGitHubDiscussion{
+			Owner:            testIssueOwner,
+			RepoName:         testIssueRepoName,
+			DiscussionNumber: 3,
+			Category:         category,
+			Answered:         answered,
+		}
+	}
+
+	cases := []struct {
+		name          string
+		discussionURL string
+		policy        *Policy
+		response      string
+		want          *pluginGitHubDiscussion
+		wantErr       string
+		wantReason    Reason
+	}{
+		{
+			name:          "success",
+			discussionURL: testGitHubDiscussionURL,
+			policy:        &Policy{},
+			response:      discussion(false, false, false, recent, "General"),
+			want:          wantInfo("General", false),
+		},
+		{
+			name:          "invalid_url",
+			discussionURL: "https://github.com/test-owner/test-repo/discussions/abc",
+			policy:        &Policy{},
+			wantErr:       "invalid discussion url",
+			wantReason:    ReasonReferenceMalformed,
+		},
+		{
+			name:          "not_found",
+			discussionURL: testGitHubDiscussionURL,
+			policy:        &Policy{},
+			response:      `{"data": {"repository": {"discussion": null}}, "errors": [{"type": "NOT_FOUND", "message": "Could not resolve to a Discussion"}]}`,
+			wantErr:       "discussion not found",
+			wantReason:    ReasonReferenceNotFound,
+		},
+		{
+			name:          "graphql_error",
+			discussionURL: testGitHubDiscussionURL,
+			policy:        &Policy{},
+			response:      `{"errors": [{"type": "INTERNAL", "message": "boom"}]}`,
+			wantErr:       "failed to get discussion info",
+		},
+		{
+			name:          "closed",
+			discussionURL: testGitHubDiscussionURL,
+			policy:        &Policy{},
+			response:      discussion(true, false, false, recent, "General"),
+			want:          wantInfo("General", false),
+			wantErr:       "discussion is closed",
+			wantReason:    ReasonDiscussionClosed,
+		},
+		{
+			name:          "locked",
+			discussionURL: testGitHubDiscussionURL,
+			policy:        &Policy{},
+			response:      discussion(false, true, false, recent, "General"),
+			want:          wantInfo("General", false),
+			wantErr:       "discussion is locked",
+			wantReason:    ReasonDiscussionLocked,
+		},
+		{
+			name:          "locked_allowed",
+			discussionURL: testGitHubDiscussionURL,
+			policy:        &Policy{DiscussionAllowLocked: true},
+			response:      discussion(false, true, false, recent, "General"),
+			want:          wantInfo("General", false),
+		},
+		{
+			name:          "category_allowed",
+			discussionURL: testGitHubDiscussionURL,
+			policy:        &Policy{DiscussionCategories: []string{"change reviews"}},
+			response:      discussion(false, false, false, recent, "Change Reviews"),
+			want:          wantInfo("Change Reviews", false),
+		},
+		{
+			name:          "category_not_allowed",
+			discussionURL: testGitHubDiscussionURL,
+			policy:        &Policy{DiscussionCategories: []string{"Change Reviews"}},
+			response:      discussion(false, false, false, recent, "General"),
+			want:          wantInfo("General", false),
+			wantErr:       `discussion category "General" is not one of ["Change Reviews"]`,
+			wantReason:    ReasonDiscussionNotAllowed,
+		},
+		{
+			name:          "must_be_answered",
+			discussionURL: testGitHubDiscussionURL,
+			policy:        &Policy{DiscussionAnswered: discussionAnsweredAnswered},
+			response:      discussion(false, false, false, recent, "Q&A"),
+			want:          wantInfo("Q&A", false),
+			wantErr:       "discussion must be answered",
+			wantReason:    ReasonDiscussionNotAllowed,
+		},
+		{
+			name:          "must_be_unanswered",
+			discussionURL: testGitHubDiscussionURL,
+			policy:        &Policy{DiscussionAnswered: discussionAnsweredUnanswered},
+			response:      discussion(false, false, true, recent, "Q&A"),
+			want:          wantInfo("Q&A", true),
+			wantErr:       "discussion must not be answered",
+			wantReason:    ReasonDiscussionNotAllowed,
+		},
+		{
+			name:          "too_old",
+			discussionURL: testGitHubDiscussionURL,
+			policy:        &Policy{DiscussionMaxAge: 24 * time.Hour},
+			response:      discussion(false, false, false, old, "General"),
+			want:          wantInfo("General", false),
+			wantErr:       "discussion was created more than 24h0m0s ago",
+			wantReason:    ReasonDiscussionNotAllowed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			installation := testGitHubInstallation(t, http.StatusCreated)
+			hc := newTestServer(t, testHandleGraphQL(t, func(vars map[string]any) string {
+				if got, want := vars["number"], float64(3); got != want {
+					t.Errorf("graphql variable number got %v, want %v", got, want)
+				}
+				return tc.response
+			}))
+
+			v := NewValidator(github.NewClient(hc), installation, tc.policy)
+			got, err := v.MatchDiscussion(t.Context(), tc.discussionURL)
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if got, want := testReason(err), tc.wantReason; got != want {
+				t.Errorf("reason of %v got %q, want %q", err, got, want)
+			}
+			if tc.wantErr == "" || tc.want != nil {
+				if diff := cmp.Diff(tc.want, got); diff != "" {
+					t.Errorf("MatchDiscussion() unexpected diff (-want,+got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	// Allowed enforcement levels of policy rules.
+	enforcementEnforce = "enforce"
+	enforcementWarn    = "warn"
+	enforcementOff     = "off"
+)
+
+// enforcementLevels are the allowed enforcement levels of policy rules.
+var enforcementLevels = []string{enforcementEnforce, enforcementWarn, enforcementOff}
+
+// enforceableReasons are the reasons of the policy rules whose enforcement
+// level is configurable. The other reasons, like the reference not being
+// found or closed, are always enforced.
+var enforceableReasons = []Reason{
+	ReasonRepoNotAllowed,
+	ReasonLabelMissing,
+	ReasonIssueBodyInvalid,
+	ReasonIssueTypeNotAllowed,
+	ReasonMilestoneNotAllowed,
+	ReasonProjectNotAllowed,
+	ReasonProjectStatusNotAllowed,
+	ReasonTeamNotAllowed,
+	ReasonApprovalMissing,
+	ReasonLinkMissing,
+	ReasonDiscussionLocked,
+	ReasonDiscussionNotAllowed,
+	ReasonBranchNotAllowed,
+	ReasonWorkflowNotAllowed,
+	ReasonDeploymentNotAllowed,
+}
+
+// policyRule identifies a policy rule by the option configuring it. Rules are
+// only distinguished for the reasons covering several rules.
+type policyRule string
+
+// Policy rules of the reasons covering several rules.
+const (
+	ruleRepositoryVisibilities        policyRule = "GITHUB_REPOSITORY_VISIBILITIES"
+	ruleRepositoryDenyArchived        policyRule = "GITHUB_REPOSITORY_DENY_ARCHIVED"
+	ruleRepositoryDenyForks           policyRule = "GITHUB_REPOSITORY_DENY_FORKS"
+	ruleRepositoryTopics              policyRule = "GITHUB_REPOSITORY_TOPICS"
+	ruleRepositoryProperties          policyRule = "GITHUB_REPOSITORY_PROPERTIES"
+	ruleIssueMilestones               policyRule = "GITHUB_ISSUE_MILESTONES"
+	ruleIssueRequireOpenMilestone     policyRule = "GITHUB_ISSUE_REQUIRE_OPEN_MILESTONE"
+	ruleIssueRequiredSections         policyRule = "GITHUB_ISSUE_REQUIRED_SECTIONS"
+	ruleIssueBodyPatterns             policyRule = "GITHUB_ISSUE_BODY_PATTERNS"
+	ruleIssueAuthorTeams              policyRule = "GITHUB_ISSUE_AUTHOR_TEAMS"
+	ruleIssueAssigneeTeams            policyRule = "GITHUB_ISSUE_ASSIGNEE_TEAMS"
+	ruleIssueRequireLinkedPR          policyRule = "GITHUB_ISSUE_REQUIRE_LINKED_PULL_REQUEST"
+	rulePullRequestRequireLinkedIssue policyRule = "GITHUB_PULL_REQUEST_REQUIRE_LINKED_ISSUE"
+	rulePullRequestBaseBranches       policyRule = "GITHUB_PULL_REQUEST_BASE_BRANCHES"
+	ruleWorkflowBranches              policyRule = "GITHUB_WORKFLOW_BRANCHES"
+	ruleDiscussionCategories          policyRule = "GITHUB_DISCUSSION_CATEGORIES"
+	ruleDiscussionAnswered            policyRule = "GITHUB_DISCUSSION_ANSWERED"
+	ruleDiscussionMaxAge              policyRule = "GITHUB_DISCUSSION_MAX_AGE"
+	ruleDeploymentEnvironments        policyRule = "GITHUB_DEPLOYMENT_ENVIRONMENTS"
+	ruleDeploymentAllowedStates       policyRule = "GITHUB_DEPLOYMENT_ALLOWED_STATES"
+)
+
+// policyRuleReasons are the reasons of the policy rules.
+var policyRuleReasons = map[policyRule]Reason{
+	ruleRepositoryVisibilities:        ReasonRepoNotAllowed,
+	ruleRepositoryDenyArchived:        ReasonRepoNotAllowed,
+	ruleRepositoryDenyForks:           ReasonRepoNotAllowed,
+	ruleRepositoryTopics:              ReasonRepoNotAllowed,
+	ruleRepositoryProperties:          ReasonRepoNotAllowed,
+	ruleIssueMilestones:               ReasonMilestoneNotAllowed,
+	ruleIssueRequireOpenMilestone:     ReasonMilestoneNotAllowed,
+	ruleIssueRequiredSections:         ReasonIssueBodyInvalid,
+	ruleIssueBodyPatterns:             ReasonIssueBodyInvalid,
+	ruleIssueAuthorTeams:              ReasonTeamNotAllowed,
+	ruleIssueAssigneeTeams:            ReasonTeamNotAllowed,
+	ruleIssueRequireLinkedPR:          ReasonLinkMissing,
+	rulePullRequestRequireLinkedIssue: ReasonLinkMissing,
+	rulePullRequestBaseBranches:       ReasonBranchNotAllowed,
+	ruleWorkflowBranches:              ReasonBranchNotAllowed,
+	ruleDiscussionCategories:          ReasonDiscussionNotAllowed,
+	ruleDiscussionAnswered:            ReasonDiscussionNotAllowed,
+	ruleDiscussionMaxAge:              ReasonDiscussionNotAllowed,
+	ruleDeploymentEnvironments:        ReasonDeploymentNotAllowed,
+	ruleDeploymentAllowedStates:       ReasonDeploymentNotAllowed,
+}
+
+// parseEnforcement parses the enforcement level of the policy rules with a
+// reason, in the "REASON=level" format, or of a single rule, in the
+// "RULE=level" format. It returns the reason or rule and the level.
+func parseEnforcement(s string) (string, string, error) {
+	key, level, ok := strings.Cut(s, "=")
+	if !ok {
+		return "", "", fmt.Errorf("enforcement %q must be in the format REASON=level or RULE=level", s)
+	}
+	if _, isRule := policyRuleReasons[policyRule(key)]; !isRule && !slices.Contains(enforceableReasons, Reason(key)) {
+		return "", "", fmt.Errorf("enforcement reason %q must be one of %q, or a rule in %q",
+			key, enforceableReasons, slices.Sorted(maps.Keys(policyRuleReasons)))
+	}
+	if !slices.Contains(enforcementLevels, level) {
+		return "", "", fmt.Errorf("enforcement level %q must be one of %q", level, enforcementLevels)
+	}
+	return key, level, nil
+}
+
+// enforcementLevel returns the enforcement level of the policy rule with the
+// reason, "enforce" unless configured otherwise. The level of the rule takes
+// precedence over the level of its reason.
+func (p *Policy) enforcementLevel(reason Reason, rule policyRule) string {
+	level, ruleLevel := enforcementEnforce, ""
+	for _, e := range p.Enforcement {
+		// The enforcement is validated with the policy, the last one wins.
+		key, l, err := parseEnforcement(e)
+		if err != nil {
+			continue
+		}
+		switch {
+		case rule != "" && key == string(rule):
+			ruleLevel = l
+		case key == string(reason):
+			level = l
+		}
+	}
+	if ruleLevel != "" {
+		return ruleLevel
+	}
+	return level
+}
+
+// ruleOff reports whether the policy rule with the reason is in the "off"
+// level, in which case its checks, and the GitHub API calls they need, are
+// skipped. The rule is empty for the reasons covering a single rule.
+func (v *Validator) ruleOff(reason Reason, rule policyRule) bool {
+	return v.policy.enforcementLevel(reason, rule) == enforcementOff
+}
+
+// policyWarnings collects the policy violations which were not enforced while
+// validating a reference.
+type policyWarnings struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+type policyWarningsContextKey struct{}
+
+// withPolicyWarnings returns a context collecting the policy violations which
+// are not enforced into the returned policyWarnings.
+func withPolicyWarnings(ctx context.Context) (context.Context, *policyWarnings) {
+	w := &policyWarnings{}
+	return context.WithValue(ctx, policyWarningsContextKey{}, w), w
+}
+
+// list returns the collected policy violations.
+func (w *policyWarnings) list() []error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return slices.Clone(w.errs)
+}
+
+// addPolicyWarnings adds the policy violations to the policyWarnings of the
+// context, if any.
+func addPolicyWarnings(ctx context.Context, errs ...error) {
+	if w, ok := ctx.Value(policyWarningsContextKey{}).(*policyWarnings); ok {
+		w.mu.Lock()
+		w.errs = append(w.errs, errs...)
+		w.mu.Unlock()
+	}
+}
+
+// enforce returns the error unless it is a policy violation whose rule is not
+// enforced. Violations of rules in the "warn" level are collected into the
+// policyWarnings of the context, if any, and those in the "off" level are
+// ignored.
+func (v *Validator) enforce(ctx context.Context, err error) error {
+	var re *reasonError
+	if !errors.As(err, &re) {
+		return err
+	}
+
+	switch v.policy.enforcementLevel(re.reason, re.rule) {
+	case enforcementWarn:
+		addPolicyWarnings(ctx, err)
+		return nil
+	case enforcementOff:
+		return nil
+	default:
+		return err
+	}
+}
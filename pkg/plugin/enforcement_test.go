@@ -0,0 +1,377 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v55/github"
+
+	jvspb "github.com/abcxyz/jvs/apis/v0"
+	"github.com/abcxyz/pkg/testutil"
+)
+
+// testEnforcingMatcher matches every issue, enforcing the given violations
+// with the policy.
+type testEnforcingMatcher struct {
+	testReferenceMatcher
+	policy     *Policy
+	violations []error
+}
+
+func (t *testEnforcingMatcher) MatchIssue(ctx context.Context, issueURL string) (*pluginGitHubIssue, error) {
+	v := &Validator{policy: t.policy}
+	for _, violation := range t.violations {
+		if err := v.enforce(ctx, violation); err != nil {
+			return nil, err
+		}
+	}
+	return parseIssueInfoFromURL(issueURL)
+}
+
+func TestParseEnforcement(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		s         string
+		wantKey   string
+		wantLevel string
+		wantErr   string
+	}{
+		{
+			name:      "warn",
+			s:         "LABEL_MISSING=warn",
+			wantKey:   "LABEL_MISSING",
+			wantLevel: enforcementWarn,
+		},
+		{
+			name:      "off",
+			s:         "APPROVAL_MISSING=off",
+			wantKey:   "APPROVAL_MISSING",
+			wantLevel: enforcementOff,
+		},
+		{
+			name:      "rule",
+			s:         "GITHUB_REPOSITORY_DENY_ARCHIVED=warn",
+			wantKey:   "GITHUB_REPOSITORY_DENY_ARCHIVED",
+			wantLevel: enforcementWarn,
+		},
+		{
+			name:    "missing_level",
+			s:       "LABEL_MISSING",
+			wantErr: `enforcement "LABEL_MISSING" must be in the format REASON=level or RULE=level`,
+		},
+		{
+			name:    "unknown_rule",
+			s:       "GITHUB_ISSUE_LABELS=warn",
+			wantErr: `enforcement reason "GITHUB_ISSUE_LABELS" must be one of`,
+		},
+		{
+			name:    "not_enforceable",
+			s:       "REFERENCE_NOT_FOUND=off",
+			wantErr: `enforcement reason "REFERENCE_NOT_FOUND" must be one of`,
+		},
+		{
+			name:    "invalid_level",
+			s:       "LABEL_MISSING=audit",
+			wantErr: `enforcement level "audit" must be one of ["enforce" "warn" "off"]`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			key, level, err := parseEnforcement(tc.s)
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if key != tc.wantKey || level != tc.wantLevel {
+				t.Errorf("parseEnforcement(%q) = (%q, %q), want (%q, %q)", tc.s, key, level, tc.wantKey, tc.wantLevel)
+			}
+		})
+	}
+}
+
+func TestValidator_Enforce(t *testing.T) {
+	t.Parallel()
+
+	labelMissing := invalidf(ReasonLabelMissing, "issues require labels")
+	archived := violationf(ruleRepositoryDenyArchived, "repository is archived")
+	topics := violationf(ruleRepositoryTopics, "repository has none of the topics")
+	internalErr := fmt.Errorf("injected error")
+
+	cases := []struct {
+		name         string
+		enforcement  []string
+		err          error
+		wantErr      error
+		wantWarnings []error
+	}{
+		{
+			name: "no_error",
+		},
+		{
+			name:    "enforced_by_default",
+			err:     labelMissing,
+			wantErr: labelMissing,
+		},
+		{
+			name:        "enforced",
+			enforcement: []string{"LABEL_MISSING=warn", "LABEL_MISSING=enforce"},
+			err:         labelMissing,
+			wantErr:     labelMissing,
+		},
+		{
+			name:         "warn",
+			enforcement:  []string{"LABEL_MISSING=warn"},
+			err:          labelMissing,
+			wantWarnings: []error{labelMissing},
+		},
+		{
+			name:        "off",
+			enforcement: []string{"LABEL_MISSING=off"},
+			err:         labelMissing,
+		},
+		{
+			name:        "other_reason",
+			enforcement: []string{"APPROVAL_MISSING=off"},
+			err:         labelMissing,
+			wantErr:     labelMissing,
+		},
+		{
+			name:         "rule_warn",
+			enforcement:  []string{"GITHUB_REPOSITORY_DENY_ARCHIVED=warn"},
+			err:          archived,
+			wantWarnings: []error{archived},
+		},
+		{
+			name:        "other_rule_of_reason",
+			enforcement: []string{"GITHUB_REPOSITORY_DENY_ARCHIVED=warn"},
+			err:         topics,
+			wantErr:     topics,
+		},
+		{
+			name:         "reason_of_rule",
+			enforcement:  []string{"REPO_NOT_ALLOWED=warn"},
+			err:          topics,
+			wantWarnings: []error{topics},
+		},
+		{
+			name:        "rule_over_reason",
+			enforcement: []string{"GITHUB_REPOSITORY_TOPICS=enforce", "REPO_NOT_ALLOWED=off"},
+			err:         topics,
+			wantErr:     topics,
+		},
+		{
+			name:        "internal_error",
+			enforcement: []string{"LABEL_MISSING=off"},
+			err:         internalErr,
+			wantErr:     internalErr,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, warnings := withPolicyWarnings(t.Context())
+			v := &Validator{policy: &Policy{Enforcement: tc.enforcement}}
+			if got, want := v.enforce(ctx, tc.err), tc.wantErr; got != want {
+				t.Errorf("enforce() got %v, want %v", got, want)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, warnings.list(), cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("warnings unexpected diff (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_PolicyWarnings(t *testing.T) {
+	t.Parallel()
+
+	policy := &Policy{Enforcement: []string{"LABEL_MISSING=warn", "APPROVAL_MISSING=warn"}}
+	violations := []error{
+		invalidf(ReasonLabelMissing, "issues require labels"),
+		invalidf(ReasonApprovalMissing, "issue is not approved"),
+		invalidf(ReasonLabelMissing, "issues require other labels"),
+	}
+
+	cases := []struct {
+		name    string
+		value   string
+		want    []string
+		wantKey map[string]string
+	}{
+		{
+			name:  "single_reference",
+			value: testGitHubIssueURL,
+			want: []string{
+				"[LABEL_MISSING] invalid justification: issues require labels",
+				"[APPROVAL_MISSING] invalid justification: issue is not approved",
+				"[LABEL_MISSING] invalid justification: issues require other labels",
+			},
+			wantKey: map[string]string{
+				"github_policy_warnings":  "LABEL_MISSING,APPROVAL_MISSING",
+				"github_policy_warning_0": "[LABEL_MISSING] invalid justification: issues require labels",
+				"github_policy_warning_1": "[APPROVAL_MISSING] invalid justification: issue is not approved",
+				"github_policy_warning_2": "[LABEL_MISSING] invalid justification: issues require other labels",
+			},
+		},
+		{
+			name:  "multiple_references",
+			value: testGitHubIssueURL + " https://github.com/test-owner/test-repo/issues/2",
+			want: []string{
+				"[LABEL_MISSING] reference 0 " + testGitHubIssueURL + ": invalid justification: issues require labels",
+				"[APPROVAL_MISSING] reference 0 " + testGitHubIssueURL + ": invalid justification: issue is not approved",
+				"[LABEL_MISSING] reference 0 " + testGitHubIssueURL + ": invalid justification: issues require other labels",
+				"[LABEL_MISSING] reference 1 https://github.com/test-owner/test-repo/issues/2: invalid justification: issues require labels",
+				"[APPROVAL_MISSING] reference 1 https://github.com/test-owner/test-repo/issues/2: invalid justification: issue is not approved",
+				"[LABEL_MISSING] reference 1 https://github.com/test-owner/test-repo/issues/2: invalid justification: issues require other labels",
+			},
+			wantKey: map[string]string{
+				"github_ref_0_policy_warnings":  "LABEL_MISSING,APPROVAL_MISSING",
+				"github_ref_1_policy_warnings":  "LABEL_MISSING,APPROVAL_MISSING",
+				"github_ref_1_policy_warning_1": "[APPROVAL_MISSING] invalid justification: issue is not approved",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &GitHubPlugin{
+				categories: map[string]*pluginCategory{
+					githubCategory: {
+						validator: &testEnforcingMatcher{policy: policy, violations: violations},
+					},
+				},
+			}
+			gotResq, err := p.Validate(t.Context(), &jvspb.ValidateJustificationRequest{
+				Justification: &jvspb.Justification{
+					Category: githubCategory,
+					Value:    tc.value,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !gotResq.GetValid() {
+				t.Errorf("Validate() invalid, errors: %q", gotResq.GetError())
+			}
+			if diff := cmp.Diff(tc.want, gotResq.GetWarning()); diff != "" {
+				t.Errorf("warnings unexpected diff (-want,+got):\n%s", diff)
+			}
+			for k, want := range tc.wantKey {
+				if got := gotResq.GetAnnotation()[k]; got != want {
+					t.Errorf("annotation %s got %q, want %q", k, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchIssue_Enforcement(t *testing.T) {
+	t.Parallel()
+
+	issue := []byte(`{"state": "open", "user": {"login": "alice"}, "milestone": {"title": "v1", "state": "open"}}`)
+
+	cases := []struct {
+		name        string
+		policy      *Policy
+		wantErr     string
+		wantReasons []Reason
+		wantCalls   int
+	}{
+		{
+			name: "warn_continues",
+			policy: &Policy{
+				IssueMilestones:       []string{"v2"},
+				IssueRequiredSections: []string{"Impact"},
+				Enforcement:           []string{"GITHUB_ISSUE_MILESTONES=warn"},
+			},
+			wantErr:     `issue body is missing required sections ["Impact"]`,
+			wantReasons: []Reason{ReasonMilestoneNotAllowed},
+		},
+		{
+			name: "all_warned",
+			policy: &Policy{
+				IssueMilestones:       []string{"v2"},
+				IssueRequiredSections: []string{"Impact"},
+				IssueAuthorTeams:      []string{"my-org/oncall"},
+				Enforcement:           []string{"MILESTONE_NOT_ALLOWED=warn", "ISSUE_BODY_INVALID=warn", "TEAM_NOT_ALLOWED=warn"},
+			},
+			wantReasons: []Reason{ReasonMilestoneNotAllowed, ReasonIssueBodyInvalid, ReasonTeamNotAllowed},
+			wantCalls:   1,
+		},
+		{
+			// The team backend and the approval endpoints fail if called.
+			name: "off_skips_checks",
+			policy: &Policy{
+				IssueAuthorTeams: []string{"my-org/oncall"},
+				ApprovalTeams:    []string{"my-org/sre"},
+				Enforcement:      []string{"TEAM_NOT_ALLOWED=off", "APPROVAL_MISSING=off"},
+			},
+		},
+		{
+			name: "rule_off",
+			policy: &Policy{
+				IssueAuthorTeams:   []string{"my-org/oncall"},
+				IssueAssigneeTeams: []string{"my-org/sre"},
+				Enforcement:        []string{"GITHUB_ISSUE_AUTHOR_TEAMS=off"},
+			},
+			wantErr: `issue has no assignee who is a member of ["my-org/sre"]`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			installation := testGitHubInstallation(t, http.StatusCreated)
+			hc := newTestServer(t, testHandleIssueReturn(t, issue))
+
+			backend := &testTeamBackend{}
+			if tc.wantCalls == 0 {
+				backend.err = fmt.Errorf("unexpected team membership check")
+			}
+			v := NewValidator(github.NewClient(hc), installation, tc.policy)
+			v.teams = newTeamResolver(backend, 0)
+
+			ctx, warnings := withPolicyWarnings(t.Context())
+			_, err := v.MatchIssue(ctx, testGitHubIssueURL)
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			var gotReasons []Reason
+			for _, w := range warnings.list() {
+				gotReasons = append(gotReasons, reasonOf(w))
+			}
+			if diff := cmp.Diff(tc.wantReasons, gotReasons); diff != "" {
+				t.Errorf("warning reasons unexpected diff (-want,+got):\n%s", diff)
+			}
+			if got, want := backend.calls, tc.wantCalls; got != want {
+				t.Errorf("team membership checks got %d, want %d", got, want)
+			}
+		})
+	}
+}
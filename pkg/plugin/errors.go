@@ -14,6 +14,12 @@
 
 package plugin
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
 type Error string
 
 func (e Error) Error() string {
@@ -21,3 +27,157 @@ func (e Error) Error() string {
 }
 
 const errInvalidJustification = Error("invalid justification")
+
+// ErrMissingSnapshot is returned when annotations do not contain an issue
+// snapshot hash to verify against.
+const ErrMissingSnapshot = Error("missing issue snapshot")
+
+// Reason is a stable, machine-readable code of why a justification is invalid.
+// It prefixes the error messages of invalid justifications in brackets, e.g.
+// "[ISSUE_CLOSED] invalid justification: issue is in state: closed, ...".
+type Reason string
+
+const (
+	// ReasonInvalid is the reason of invalid justifications without a more
+	// specific reason.
+	ReasonInvalid Reason = "INVALID"
+
+	// ReasonCategoryUnknown means the justification category is not validated
+	// by the plugin.
+	ReasonCategoryUnknown Reason = "CATEGORY_UNKNOWN"
+	// ReasonTooManyReferences means the justification has more references than
+	// allowed.
+	ReasonTooManyReferences Reason = "TOO_MANY_REFERENCES"
+	// ReasonReferenceMalformed means the reference is not a well-formed URL of
+	// a supported GitHub object.
+	ReasonReferenceMalformed Reason = "REFERENCE_MALFORMED"
+	// ReasonReferenceNotFound means the referenced GitHub object doesn't exist
+	// or isn't accessible to the plugin.
+	ReasonReferenceNotFound Reason = "REFERENCE_NOT_FOUND"
+	// ReasonRepoNotAllowed means the repository of the reference doesn't
+	// satisfy the repository policy.
+	ReasonRepoNotAllowed Reason = "REPO_NOT_ALLOWED"
+
+	// ReasonIssueClosed means the issue is not open.
+	ReasonIssueClosed Reason = "ISSUE_CLOSED"
+	// ReasonLabelMissing means the issue lacks labels required in its
+	// repository.
+	ReasonLabelMissing Reason = "LABEL_MISSING"
+	// ReasonIssueBodyInvalid means the issue body lacks required sections or
+	// patterns.
+	ReasonIssueBodyInvalid Reason = "ISSUE_BODY_INVALID"
+	// ReasonIssueTypeNotAllowed means the issue has no or another issue type.
+	ReasonIssueTypeNotAllowed Reason = "ISSUE_TYPE_NOT_ALLOWED"
+	// ReasonMilestoneNotAllowed means the issue milestone is missing, not
+	// allowed, closed or past due.
+	ReasonMilestoneNotAllowed Reason = "MILESTONE_NOT_ALLOWED"
+	// ReasonProjectNotAllowed means the issue is not in the required project.
+	ReasonProjectNotAllowed Reason = "PROJECT_NOT_ALLOWED"
+	// ReasonProjectStatusNotAllowed means the project item status is not
+	// allowed.
+	ReasonProjectStatusNotAllowed Reason = "PROJECT_STATUS_NOT_ALLOWED"
+	// ReasonTeamNotAllowed means the issue author or assignees are not members
+	// of the required teams.
+	ReasonTeamNotAllowed Reason = "TEAM_NOT_ALLOWED"
+	// ReasonApprovalMissing means the issue is not approved by a member of the
+	// approval teams.
+	ReasonApprovalMissing Reason = "APPROVAL_MISSING"
+	// ReasonLinkMissing means the issue or pull request lacks a required
+	// linked pull request or issue.
+	ReasonLinkMissing Reason = "LINK_MISSING"
+
+	// ReasonDiscussionClosed means the discussion is closed.
+	ReasonDiscussionClosed Reason = "DISCUSSION_CLOSED"
+	// ReasonDiscussionLocked means the discussion is locked.
+	ReasonDiscussionLocked Reason = "DISCUSSION_LOCKED"
+	// ReasonDiscussionNotAllowed means the discussion category, answer state
+	// or age doesn't satisfy the discussion policy.
+	ReasonDiscussionNotAllowed Reason = "DISCUSSION_NOT_ALLOWED"
+
+	// ReasonPullRequestClosed means the pull request is closed, or merged
+	// outside the allowed window.
+	ReasonPullRequestClosed Reason = "PULL_REQUEST_CLOSED"
+	// ReasonBranchNotAllowed means the pull request base branch or workflow
+	// run branch is not allowed.
+	ReasonBranchNotAllowed Reason = "BRANCH_NOT_ALLOWED"
+	// ReasonCommitWithoutPullRequest means the commit has no pull request
+	// satisfying the policy.
+	ReasonCommitWithoutPullRequest Reason = "COMMIT_WITHOUT_PULL_REQUEST"
+
+	// ReasonWorkflowRunCompleted means the workflow run is not queued or in
+	// progress, nor completed within the allowed window.
+	ReasonWorkflowRunCompleted Reason = "WORKFLOW_RUN_COMPLETED"
+	// ReasonWorkflowNotAllowed means the workflow of the run is not allowed.
+	ReasonWorkflowNotAllowed Reason = "WORKFLOW_NOT_ALLOWED"
+	// ReasonDeploymentNotAllowed means the deployment environment or state is
+	// not allowed.
+	ReasonDeploymentNotAllowed Reason = "DEPLOYMENT_NOT_ALLOWED"
+	// ReasonSecurityStateNotAllowed means the security advisory or alert is
+	// not in an open state.
+	ReasonSecurityStateNotAllowed Reason = "SECURITY_STATE_NOT_ALLOWED"
+)
+
+// reasonError is an invalid justification error with its reason, and the
+// policy rule violated if the reason covers several rules.
+type reasonError struct {
+	reason Reason
+	rule   policyRule
+	err    error
+}
+
+func (e *reasonError) Error() string {
+	return e.err.Error()
+}
+
+func (e *reasonError) Unwrap() error {
+	return e.err
+}
+
+// invalidf returns an error wrapping errInvalidJustification with the reason
+// and the formatted message.
+func invalidf(reason Reason, format string, a ...any) error {
+	return &reasonError{
+		reason: reason,
+		err:    fmt.Errorf("%w: "+format, append([]any{errInvalidJustification}, a...)...),
+	}
+}
+
+// violationf returns an invalid justification error like invalidf, violating
+// the policy rule, with the reason of the rule.
+func violationf(rule policyRule, format string, a ...any) error {
+	return &reasonError{
+		reason: policyRuleReasons[rule],
+		rule:   rule,
+		err:    fmt.Errorf("%w: "+format, append([]any{errInvalidJustification}, a...)...),
+	}
+}
+
+// reasonOf returns the reason of the invalid justification error, or
+// ReasonInvalid if it has none.
+func reasonOf(err error) Reason {
+	var re *reasonError
+	if errors.As(err, &re) {
+		return re.reason
+	}
+	return ReasonInvalid
+}
+
+// formatReason prefixes the error message with the reason.
+func formatReason(reason Reason, msg string) string {
+	return "[" + string(reason) + "] " + msg
+}
+
+// ParseReason splits an error message of an invalid justification into its
+// reason and the human readable message. It returns false if the message has
+// no reason.
+func ParseReason(msg string) (Reason, string, bool) {
+	rest, ok := strings.CutPrefix(msg, "[")
+	if !ok {
+		return "", msg, false
+	}
+	reason, text, ok := strings.Cut(rest, "] ")
+	if !ok || reason == "" || strings.ContainsAny(reason, " []") {
+		return "", msg, false
+	}
+	return Reason(reason), text, true
+}
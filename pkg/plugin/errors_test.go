@@ -0,0 +1,129 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// testReason returns the reason of err if it is an invalid justification
+// error, and an empty reason otherwise.
+func testReason(err error) Reason {
+	if !errors.Is(err, errInvalidJustification) {
+		return ""
+	}
+	return reasonOf(err)
+}
+
+func TestReasonOf(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		err     error
+		want    Reason
+		wantMsg string
+	}{
+		{
+			name:    "reason",
+			err:     invalidf(ReasonIssueClosed, "issue is in state: %s", "closed"),
+			want:    ReasonIssueClosed,
+			wantMsg: "invalid justification: issue is in state: closed",
+		},
+		{
+			name:    "wrapped",
+			err:     fmt.Errorf("commit abc: %w", invalidf(ReasonPullRequestClosed, "pull request is closed")),
+			want:    ReasonPullRequestClosed,
+			wantMsg: "commit abc: invalid justification: pull request is closed",
+		},
+		{
+			name:    "outermost_reason",
+			err:     invalidf(ReasonCommitWithoutPullRequest, "no pull request: %w", invalidf(ReasonPullRequestClosed, "pull request is closed")),
+			want:    ReasonCommitWithoutPullRequest,
+			wantMsg: "invalid justification: no pull request: invalid justification: pull request is closed",
+		},
+		{
+			name:    "no_reason",
+			err:     errors.Join(errInvalidJustification, fmt.Errorf("issue not found")),
+			want:    ReasonInvalid,
+			wantMsg: "invalid justification\nissue not found",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if !errors.Is(tc.err, errInvalidJustification) {
+				t.Errorf("errors.Is(%v, errInvalidJustification) = false, want true", tc.err)
+			}
+			if got, want := reasonOf(tc.err), tc.want; got != want {
+				t.Errorf("reasonOf() got %q, want %q", got, want)
+			}
+			if got, want := tc.err.Error(), tc.wantMsg; got != want {
+				t.Errorf("Error() got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestParseReason(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		msg        string
+		wantReason Reason
+		wantText   string
+		wantOK     bool
+	}{
+		{
+			name:       "reason",
+			msg:        formatReason(ReasonRepoNotAllowed, "invalid justification: repository a/b is archived"),
+			wantReason: ReasonRepoNotAllowed,
+			wantText:   "invalid justification: repository a/b is archived",
+			wantOK:     true,
+		},
+		{
+			name:     "no_reason",
+			msg:      "invalid justification: repository a/b is archived",
+			wantText: "invalid justification: repository a/b is archived",
+		},
+		{
+			name:     "not_a_reason",
+			msg:      "[see below] issue is closed",
+			wantText: "[see below] issue is closed",
+		},
+		{
+			name:     "empty_reason",
+			msg:      "[] issue is closed",
+			wantText: "[] issue is closed",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			reason, text, ok := ParseReason(tc.msg)
+			if reason != tc.wantReason || text != tc.wantText || ok != tc.wantOK {
+				t.Errorf("ParseReason(%q) = (%q, %q, %t), want (%q, %q, %t)",
+					tc.msg, reason, text, ok, tc.wantReason, tc.wantText, tc.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+)
+
+// graphQLRequest is the request body of a GitHub GraphQL API call.
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+// graphQLResponse is the response body of a GitHub GraphQL API call.
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []*graphQLError `json:"errors"`
+}
+
+// graphQLError is a single error returned by the GitHub GraphQL API.
+type graphQLError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	Path    []any  `json:"path"`
+}
+
+// graphQLNotFound is the error type GitHub reports when a queried object does
+// not exist or is not accessible with the token.
+const graphQLNotFound = "NOT_FOUND"
+
+// graphQLErrors is returned when the GraphQL API responds with errors.
+type graphQLErrors []*graphQLError
+
+func (e graphQLErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Message)
+	}
+	return "graphql: " + strings.Join(msgs, "; ")
+}
+
+// notFound reports whether all the errors are NOT_FOUND errors.
+func (e graphQLErrors) notFound() bool {
+	for _, err := range e {
+		if err.Type != graphQLNotFound {
+			return false
+		}
+	}
+	return len(e) > 0
+}
+
+// isGraphQLNotFound reports whether err is a GraphQL NOT_FOUND error.
+func isGraphQLNotFound(err error) bool {
+	var gerr graphQLErrors
+	return errors.As(err, &gerr) && gerr.notFound()
+}
+
+// queryGraphQL runs the GraphQL query against the GitHub API the client is
+// configured for and decodes the response data into out. The client must
+// already be authenticated.
+func queryGraphQL(ctx context.Context, c *github.Client, query string, variables map[string]any, out any) error {
+	resp, err := doGraphQL(ctx, c, query, variables)
+	if err != nil {
+		return err
+	}
+	if len(resp.Errors) > 0 {
+		return graphQLErrors(resp.Errors)
+	}
+	if err := json.Unmarshal(resp.Data, out); err != nil {
+		return fmt.Errorf("failed to decode graphql response: %w", err)
+	}
+	return nil
+}
+
+// queryGraphQLPartial runs the GraphQL query like queryGraphQL, but tolerates
+// the errors of list items, e.g. linked items in other repositories which
+// can't be read with the token. Those items are null in out, and their errors
+// are returned along with a nil error. Any other error fails the query.
+func queryGraphQLPartial(ctx context.Context, c *github.Client, query string, variables map[string]any, out any) (graphQLErrors, error) {
+	resp, err := doGraphQL(ctx, c, query, variables)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range resp.Errors {
+		if !slices.ContainsFunc(e.Path, isGraphQLListIndex) {
+			return nil, graphQLErrors(resp.Errors)
+		}
+	}
+	if err := json.Unmarshal(resp.Data, out); err != nil {
+		return nil, fmt.Errorf("failed to decode graphql response: %w", err)
+	}
+	return resp.Errors, nil
+}
+
+// isGraphQLListIndex reports whether the element of a GraphQL error path is a
+// list index, which is decoded as a number.
+func isGraphQLListIndex(elem any) bool {
+	_, ok := elem.(float64)
+	return ok
+}
+
+// doGraphQL runs the GraphQL query against the GitHub API the client is
+// configured for and returns the response.
+func doGraphQL(ctx context.Context, c *github.Client, query string, variables map[string]any) (*graphQLResponse, error) {
+	// GHES serves the REST API under /api/v3/ and the GraphQL API under
+	// /api/graphql, github.com serves both from the root.
+	endpoint := "graphql"
+	if strings.HasSuffix(c.BaseURL.Path, "/api/v3/") {
+		endpoint = "../graphql"
+	}
+
+	req, err := c.NewRequest(http.MethodPost, endpoint, &graphQLRequest{
+		Query:     query,
+		Variables: variables,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create graphql request: %w", err)
+	}
+
+	var resp graphQLResponse
+	if _, err := c.Do(ctx, req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to call graphql api: %w", err)
+	}
+	return &resp, nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	// shorthandReferenceRegExp matches the "owner/repo#number" shorthand GitHub
+	// renders for issues and pull requests.
+	shorthandReferenceRegExp = regexp.MustCompile(`^([a-zA-Z0-9-]+)\/([a-zA-Z0-9-._]+)#([0-9]+)$`)
+
+	// subpagePathRegExp matches the tabs of issues, pull requests and workflow
+	// runs, e.g. "/pull/1/files", which are dropped from suggestions.
+	subpagePathRegExp = regexp.MustCompile(`^(\/[^\/]+\/[^\/]+\/(issues|pull|discussions)\/[0-9]+)\/.*$`)
+
+	// referenceExamples are examples of the references of each kind.
+	referenceExamples = map[referenceKind]string{
+		referenceKindIssue:       "https://github.com/owner/repo/issues/1",
+		referenceKindDiscussion:  "https://github.com/owner/repo/discussions/1",
+		referenceKindProjectItem: "https://github.com/orgs/owner/projects/1?issue=owner%7Crepo%7C1",
+		referenceKindWorkflowRun: "https://github.com/owner/repo/actions/runs/1",
+		referenceKindDeployment:  "https://github.com/owner/repo/deployments/production",
+		referenceKindSecurity:    "https://github.com/owner/repo/security/advisories/GHSA-xxxx-xxxx-xxxx",
+		referenceKindPullRequest: "https://github.com/owner/repo/pull/1",
+		referenceKindCommit:      "https://github.com/owner/repo/commit/0123abc",
+	}
+
+	// referencePatterns are the URL patterns of the references of each kind.
+	referencePatterns = map[referenceKind][]string{
+		referenceKindIssue:       {issueURLPatternRegExp},
+		referenceKindDiscussion:  {discussionURLPatternRegExp},
+		referenceKindProjectItem: {projectItemURLPatternRegExp},
+		referenceKindWorkflowRun: {workflowRunURLPatternRegExp},
+		referenceKindDeployment:  {deploymentURLPatternRegExp, workflowRunURLPatternRegExp},
+		referenceKindSecurity:    {securityURLPatternRegExp},
+		referenceKindPullRequest: {pullRequestURLPatternRegExp},
+		referenceKindCommit:      {commitURLPatternRegExp, compareURLPatternRegExp},
+	}
+)
+
+// referenceHint diagnoses common mistakes in a reference of the given kind
+// which doesn't match the URL pattern of the kind, and returns a suggestion to
+// correct it.
+func referenceHint(ref string, kind referenceKind) string {
+	example := fmt.Sprintf("expected a URL like %s", referenceExamples[kind])
+
+	s := strings.TrimSpace(ref)
+	if s == "" {
+		return "the justification is empty, " + example
+	}
+	if m := shorthandReferenceRegExp.FindStringSubmatch(s); m != nil {
+		return fmt.Sprintf("did you mean %q?", fmt.Sprintf("https://github.com/%s/%s/issues/%s", m[1], m[2], m[3]))
+	}
+	if strings.HasPrefix(s, "#") {
+		return "the reference is missing the repository, " + example
+	}
+	if !strings.Contains(s, "://") {
+		s = "https://" + s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Hostname() == "" {
+		return "the reference is not a URL, " + example
+	}
+
+	var notes []string
+	switch host := strings.ToLower(u.Hostname()); host {
+	case "github.com", "www.github.com":
+	case "api.github.com":
+		// REST API URLs of issues and pull requests.
+		u.Path = strings.TrimPrefix(u.Path, "/repos")
+		u.Path = strings.Replace(u.Path, "/pulls/", "/pull/", 1)
+	default:
+		return fmt.Sprintf("only references on github.com are supported, not on %s, %s", host, example)
+	}
+	u.Scheme, u.Host, u.User = "https", "github.com", nil
+
+	if f := u.Fragment; strings.HasPrefix(f, "issuecomment-") ||
+		strings.HasPrefix(f, "discussioncomment-") ||
+		strings.HasPrefix(f, "discussion_r") ||
+		strings.HasPrefix(f, "pullrequestreview-") {
+		notes = append(notes, "links to comments are not supported")
+	}
+	u.Fragment, u.RawFragment = "", ""
+
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+	if m := subpagePathRegExp.FindStringSubmatch(u.Path); m != nil {
+		u.Path = m[1]
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch {
+	case len(parts) >= 3 && parts[2] == "projects" && parts[0] != "orgs" && parts[0] != "users":
+		return "repository project boards are not supported, use the URL of the issue instead"
+	case len(parts) == 2 && parts[0] != "orgs" && parts[0] != "users":
+		return fmt.Sprintf("the URL of repository %s/%s is missing the issue number, %s", parts[0], parts[1], example)
+	case len(parts) == 3 && (parts[2] == "issues" || parts[2] == "pulls" || parts[2] == "discussions"):
+		return fmt.Sprintf("the URL of the %s list is missing the number, %s", parts[2], example)
+	case len(parts) == 4 && (parts[2] == "issues" || parts[2] == "discussions") && parts[3] == "new":
+		return fmt.Sprintf("the URL of a new %s form is missing the number, %s", strings.TrimSuffix(parts[2], "s"), example)
+	case len(parts) >= 4 && parts[2] == "pulls":
+		parts[2] = "pull"
+		u.Path = "/" + strings.Join(parts, "/")
+	}
+
+	// Only project items and deployments of workflow runs use the query.
+	if parts[0] != "orgs" && parts[0] != "users" && !isDeploymentReference(u) {
+		u.RawQuery = ""
+	}
+
+	candidate := u.String()
+	if candidate == ref || !matchesReferencePattern(candidate) {
+		return example
+	}
+	hint := fmt.Sprintf("did you mean %q?", candidate)
+	if len(notes) > 0 {
+		hint += " " + strings.Join(notes, ", ")
+	}
+	return hint
+}
+
+// matchesReferencePattern reports whether the URL matches the pattern of the
+// reference kind of its path.
+func matchesReferencePattern(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	base := (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}).String()
+	for _, pattern := range referencePatterns[referenceKindFromURL(s)] {
+		if match, _ := regexp.MatchString(pattern, base); match {
+			return true
+		}
+	}
+	return false
+}
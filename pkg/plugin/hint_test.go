@@ -0,0 +1,188 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"testing"
+)
+
+func TestReferenceHint(t *testing.T) {
+	t.Parallel()
+
+	// The corpus of bad references pasted as justifications.
+	cases := []struct {
+		name string
+		ref  string
+		kind referenceKind
+		want string
+	}{
+		{
+			name: "empty",
+			ref:  "  ",
+			want: "the justification is empty, expected a URL like https://github.com/owner/repo/issues/1",
+		},
+		{
+			name: "free_text",
+			ref:  "fixing prod outage",
+			want: "the reference is not a URL, expected a URL like https://github.com/owner/repo/issues/1",
+		},
+		{
+			name: "shorthand",
+			ref:  "my-org/my-repo#123",
+			want: `did you mean "https://github.com/my-org/my-repo/issues/123"?`,
+		},
+		{
+			name: "number_only",
+			ref:  "#123",
+			want: "the reference is missing the repository, expected a URL like https://github.com/owner/repo/issues/1",
+		},
+		{
+			name: "http",
+			ref:  "http://github.com/my-org/my-repo/issues/1",
+			want: `did you mean "https://github.com/my-org/my-repo/issues/1"?`,
+		},
+		{
+			name: "www",
+			ref:  "https://www.github.com/my-org/my-repo/issues/1",
+			want: `did you mean "https://github.com/my-org/my-repo/issues/1"?`,
+		},
+		{
+			name: "no_scheme",
+			ref:  "github.com/my-org/my-repo/issues/1",
+			want: `did you mean "https://github.com/my-org/my-repo/issues/1"?`,
+		},
+		{
+			name: "uppercase_host",
+			ref:  "HTTPS://GitHub.com/my-org/my-repo/issues/1",
+			want: `did you mean "https://github.com/my-org/my-repo/issues/1"?`,
+		},
+		{
+			name: "trailing_slash",
+			ref:  "https://github.com/my-org/my-repo/issues/1/",
+			want: `did you mean "https://github.com/my-org/my-repo/issues/1"?`,
+		},
+		{
+			name: "query",
+			ref:  "https://github.com/my-org/my-repo/issues/1?utm_source=slack",
+			want: `did you mean "https://github.com/my-org/my-repo/issues/1"?`,
+		},
+		{
+			name: "issue_comment_permalink",
+			ref:  "https://github.com/my-org/my-repo/issues/1#issuecomment-1234567",
+			want: `did you mean "https://github.com/my-org/my-repo/issues/1"? links to comments are not supported`,
+		},
+		{
+			name: "review_comment_permalink",
+			ref:  "https://github.com/my-org/my-repo/pull/2#discussion_r1234567",
+			kind: referenceKindPullRequest,
+			want: `did you mean "https://github.com/my-org/my-repo/pull/2"? links to comments are not supported`,
+		},
+		{
+			name: "discussion_comment_permalink",
+			ref:  "https://github.com/my-org/my-repo/discussions/3#discussioncomment-42",
+			kind: referenceKindDiscussion,
+			want: `did you mean "https://github.com/my-org/my-repo/discussions/3"? links to comments are not supported`,
+		},
+		{
+			name: "pull_request_files_tab",
+			ref:  "https://github.com/my-org/my-repo/pull/2/files",
+			kind: referenceKindPullRequest,
+			want: `did you mean "https://github.com/my-org/my-repo/pull/2"?`,
+		},
+		{
+			name: "pulls_instead_of_pull",
+			ref:  "https://github.com/my-org/my-repo/pulls/2",
+			want: `did you mean "https://github.com/my-org/my-repo/pull/2"?`,
+		},
+		{
+			name: "api_url",
+			ref:  "https://api.github.com/repos/my-org/my-repo/issues/1",
+			want: `did you mean "https://github.com/my-org/my-repo/issues/1"?`,
+		},
+		{
+			name: "api_pull_request_url",
+			ref:  "https://api.github.com/repos/my-org/my-repo/pulls/2",
+			want: `did you mean "https://github.com/my-org/my-repo/pull/2"?`,
+		},
+		{
+			name: "http_deployment_of_workflow_run",
+			ref:  "http://github.com/my-org/my-repo/actions/runs/5?environment=production",
+			kind: referenceKindDeployment,
+			want: `did you mean "https://github.com/my-org/my-repo/actions/runs/5?environment=production"?`,
+		},
+		{
+			name: "http_project_item",
+			ref:  "http://github.com/orgs/my-org/projects/5?pane=issue&issue=my-org%7Cmy-repo%7C1",
+			kind: referenceKindProjectItem,
+			want: `did you mean "https://github.com/orgs/my-org/projects/5?pane=issue&issue=my-org%7Cmy-repo%7C1"?`,
+		},
+		{
+			name: "enterprise_server_host",
+			ref:  "https://github.example.com/my-org/my-repo/issues/1",
+			want: "only references on github.com are supported, not on github.example.com, expected a URL like https://github.com/owner/repo/issues/1",
+		},
+		{
+			name: "other_host",
+			ref:  "https://gitlab.com/my-org/my-repo/-/issues/1",
+			want: "only references on github.com are supported, not on gitlab.com, expected a URL like https://github.com/owner/repo/issues/1",
+		},
+		{
+			name: "repository_project_board",
+			ref:  "https://github.com/my-org/my-repo/projects/1",
+			want: "repository project boards are not supported, use the URL of the issue instead",
+		},
+		{
+			name: "repository",
+			ref:  "https://github.com/my-org/my-repo",
+			want: "the URL of repository my-org/my-repo is missing the issue number, expected a URL like https://github.com/owner/repo/issues/1",
+		},
+		{
+			name: "issue_list",
+			ref:  "https://github.com/my-org/my-repo/issues?q=is%3Aopen",
+			want: "the URL of the issues list is missing the number, expected a URL like https://github.com/owner/repo/issues/1",
+		},
+		{
+			name: "missing_issue_number",
+			ref:  "https://github.com/my-org/my-repo/issues/",
+			want: "the URL of the issues list is missing the number, expected a URL like https://github.com/owner/repo/issues/1",
+		},
+		{
+			name: "new_issue_form",
+			ref:  "https://github.com/my-org/my-repo/issues/new",
+			want: "the URL of a new issue form is missing the number, expected a URL like https://github.com/owner/repo/issues/1",
+		},
+		{
+			name: "non_numeric_issue_number",
+			ref:  "https://github.com/my-org/my-repo/issues/abc",
+			want: "expected a URL like https://github.com/owner/repo/issues/1",
+		},
+		{
+			name: "truncated_commit",
+			ref:  "https://github.com/my-org/my-repo/commit/abc",
+			kind: referenceKindCommit,
+			want: "expected a URL like https://github.com/owner/repo/commit/0123abc",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got, want := referenceHint(tc.ref, tc.kind), tc.want; got != want {
+				t.Errorf("referenceHint(%q) got %q, want %q", tc.ref, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+)
+
+// issueTypeQuery fetches the type of the issue. The client library doesn't
+// support issue types.
+const issueTypeQuery = `query($owner: String!, $repo: String!, $number: Int!) {
+  repository(owner: $owner, name: $repo) {
+    issue(number: $number) {
+      issueType {
+        name
+      }
+    }
+  }
+}`
+
+// issueTypeQueryResult is the response data of issueTypeQuery.
+type issueTypeQueryResult struct {
+	Repository *struct {
+		Issue *struct {
+			IssueType *struct {
+				Name string `json:"name"`
+			} `json:"issueType"`
+		} `json:"issue"`
+	} `json:"repository"`
+}
+
+// validateIssueType verifies the issue type is one of the allowed types, and
+// records it. Issues of organizations without issue types have no type.
+func (v *Validator) validateIssueType(ctx context.Context, c *github.Client, pi *pluginGitHubIssue) error {
+	allowed := v.policy.IssueTypes
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	var result issueTypeQueryResult
+	if err := queryGraphQL(ctx, c, issueTypeQuery, map[string]any{
+		"owner":  pi.Owner,
+		"repo":   pi.RepoName,
+		"number": pi.IssueNumber,
+	}, &result); err != nil {
+		return fmt.Errorf("failed to get issue type: %w", err)
+	}
+	if result.Repository == nil || result.Repository.Issue == nil {
+		return invalidf(ReasonReferenceNotFound, "issue not found")
+	}
+	if t := result.Repository.Issue.IssueType; t != nil {
+		pi.Type = t.Name
+	}
+
+	if pi.Type == "" {
+		return invalidf(ReasonIssueTypeNotAllowed, "issue has no type, expected one of %q", allowed)
+	}
+	if !slices.ContainsFunc(allowed, func(t string) bool { return strings.EqualFold(t, pi.Type) }) {
+		return invalidf(ReasonIssueTypeNotAllowed, "issue type %q is not one of %q", pi.Type, allowed)
+	}
+	return nil
+}
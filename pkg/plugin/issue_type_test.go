@@ -0,0 +1,91 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/abcxyz/pkg/testutil"
+)
+
+func TestMatchIssue_IssueType(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		policy      *Policy
+		response    string
+		wantType    string
+		wantErr     string
+		wantInvalid bool
+	}{
+		{
+			name:   "no_policy",
+			policy: &Policy{},
+		},
+		{
+			name:     "allowed",
+			policy:   &Policy{IssueTypes: []string{"incident", "Change"}},
+			response: `{"data": {"repository": {"issue": {"issueType": {"name": "Incident"}}}}}`,
+			wantType: "Incident",
+		},
+		{
+			name:        "not_allowed",
+			policy:      &Policy{IssueTypes: []string{"Incident"}},
+			response:    `{"data": {"repository": {"issue": {"issueType": {"name": "Bug"}}}}}`,
+			wantType:    "Bug",
+			wantErr:     `issue type "Bug" is not one of ["Incident"]`,
+			wantInvalid: true,
+		},
+		{
+			name:        "no_type",
+			policy:      &Policy{IssueTypes: []string{"Incident"}},
+			response:    `{"data": {"repository": {"issue": {"issueType": null}}}}`,
+			wantErr:     "issue has no type",
+			wantInvalid: true,
+		},
+		{
+			name:     "graphql_error",
+			policy:   &Policy{IssueTypes: []string{"Incident"}},
+			response: `{"errors": [{"type": "INTERNAL", "message": "boom"}]}`,
+			wantErr:  "failed to get issue type",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			installation := testGitHubInstallation(t, http.StatusCreated)
+			hc := newTestServer(t, testHandleIssueAndGraphQL(t, []byte(`{"state": "open"}`), tc.response))
+
+			v := NewValidator(github.NewClient(hc), installation, tc.policy)
+			got, err := v.MatchIssue(t.Context(), testGitHubIssueURL)
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if got, want := errors.Is(err, errInvalidJustification), tc.wantInvalid; got != want {
+				t.Errorf("errors.Is(%v, errInvalidJustification) = %t, want %t", err, got, want)
+			}
+			if got, want := got.Type, tc.wantType; got != want {
+				t.Errorf("Type got %q, want %q", got, want)
+			}
+		})
+	}
+}
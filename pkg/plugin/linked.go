@@ -0,0 +1,191 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abcxyz/pkg/githubauth"
+	"github.com/abcxyz/pkg/logging"
+)
+
+const (
+	// maxLinkedItems bounds the number of linked pull requests or issues
+	// fetched.
+	maxLinkedItems = 20
+
+	// issueLinkedPullRequestsQuery fetches the pull requests linked to the
+	// issue in its Development section, either manually or by closing
+	// keywords. Pull requests which only mention the issue, shown as
+	// cross-referenced or connected events of its timeline, are not linked.
+	issueLinkedPullRequestsQuery = `query($owner: String!, $repo: String!, $number: Int!, $first: Int!) {
+  repository(owner: $owner, name: $repo) {
+    issue(number: $number) {
+      closedByPullRequestsReferences(first: $first, includeClosedPrs: true) {
+        nodes {
+          url
+          state
+        }
+      }
+    }
+  }
+}`
+
+	// pullRequestLinkedIssuesQuery fetches the issues the pull request closes.
+	pullRequestLinkedIssuesQuery = `query($owner: String!, $repo: String!, $number: Int!, $first: Int!) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $number) {
+      closingIssuesReferences(first: $first) {
+        nodes {
+          url
+        }
+      }
+    }
+  }
+}`
+)
+
+// issueLinkedPullRequestsQueryResult is the response data of
+// issueLinkedPullRequestsQuery.
+type issueLinkedPullRequestsQueryResult struct {
+	Repository *struct {
+		Issue *struct {
+			ClosedByPullRequestsReferences struct {
+				Nodes []*struct {
+					URL   string `json:"url"`
+					State string `json:"state"`
+				} `json:"nodes"`
+			} `json:"closedByPullRequestsReferences"`
+		} `json:"issue"`
+	} `json:"repository"`
+}
+
+// pullRequestLinkedIssuesQueryResult is the response data of
+// pullRequestLinkedIssuesQuery.
+type pullRequestLinkedIssuesQueryResult struct {
+	Repository *struct {
+		PullRequest *struct {
+			ClosingIssuesReferences struct {
+				Nodes []*struct {
+					URL string `json:"url"`
+				} `json:"nodes"`
+			} `json:"closingIssuesReferences"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+// validateLinkedPullRequests verifies the issue has at least one linked pull
+// request which is open or merged, and records them.
+func (v *Validator) validateLinkedPullRequests(ctx context.Context, pi *pluginGitHubIssue) error {
+	t, err := v.getLinkAccessToken(ctx, pi.RepoName)
+	if err != nil {
+		return fmt.Errorf("failed to get access token: %w", err)
+	}
+	c := v.client.WithAuthToken(t)
+
+	var result issueLinkedPullRequestsQueryResult
+	skipped, err := queryGraphQLPartial(ctx, c, issueLinkedPullRequestsQuery, map[string]any{
+		"owner":  pi.Owner,
+		"repo":   pi.RepoName,
+		"number": pi.IssueNumber,
+		"first":  maxLinkedItems,
+	}, &result)
+	if err != nil {
+		return fmt.Errorf("failed to get linked pull requests: %w", err)
+	}
+	logSkippedLinks(ctx, skipped)
+	if result.Repository == nil || result.Repository.Issue == nil {
+		return invalidf(ReasonReferenceNotFound, "issue not found")
+	}
+
+	for _, pr := range result.Repository.Issue.ClosedByPullRequestsReferences.Nodes {
+		// Pull requests which can't be read are null, and pull requests closed
+		// without being merged no longer implement the change.
+		if pr == nil || pr.State == "CLOSED" {
+			continue
+		}
+		pi.LinkedPullRequests = append(pi.LinkedPullRequests, pr.URL)
+	}
+	if len(pi.LinkedPullRequests) == 0 {
+		return violationf(ruleIssueRequireLinkedPR, "issue has no linked open or merged pull request")
+	}
+	return nil
+}
+
+// validateLinkedIssues verifies the pull request closes at least one issue,
+// and records them.
+func (v *Validator) validateLinkedIssues(ctx context.Context, pr *pluginGitHubPullRequest) error {
+	t, err := v.getLinkAccessToken(ctx, pr.RepoName)
+	if err != nil {
+		return fmt.Errorf("failed to get access token: %w", err)
+	}
+	c := v.client.WithAuthToken(t)
+
+	var result pullRequestLinkedIssuesQueryResult
+	skipped, err := queryGraphQLPartial(ctx, c, pullRequestLinkedIssuesQuery, map[string]any{
+		"owner":  pr.Owner,
+		"repo":   pr.RepoName,
+		"number": pr.PullNumber,
+		"first":  maxLinkedItems,
+	}, &result)
+	if err != nil {
+		return fmt.Errorf("failed to get linked issues: %w", err)
+	}
+	logSkippedLinks(ctx, skipped)
+	if result.Repository == nil || result.Repository.PullRequest == nil {
+		return invalidf(ReasonReferenceNotFound, "pull request not found")
+	}
+
+	for _, issue := range result.Repository.PullRequest.ClosingIssuesReferences.Nodes {
+		// Issues which can't be read are null.
+		if issue == nil {
+			continue
+		}
+		pr.LinkedIssues = append(pr.LinkedIssues, issue.URL)
+	}
+	if len(pr.LinkedIssues) == 0 {
+		return violationf(rulePullRequestRequireLinkedIssue, "pull request doesn't close any issue")
+	}
+	return nil
+}
+
+// logSkippedLinks logs the errors of the linked items which were skipped,
+// usually because they are in other repositories than the one the token is
+// scoped to.
+func logSkippedLinks(ctx context.Context, skipped graphQLErrors) {
+	if len(skipped) > 0 {
+		logging.FromContext(ctx).DebugContext(ctx, "skipped linked items which can't be read",
+			"error", skipped)
+	}
+}
+
+// getLinkAccessToken gets an access token with issue and pull request read
+// permission, which is required to read the links between them.
+func (v *Validator) getLinkAccessToken(ctx context.Context, repoName string) (string, error) {
+	tr := &githubauth.TokenRequest{
+		Repositories: []string{repoName},
+		Permissions: map[string]string{
+			"issues":        "read",
+			"pull_requests": "read",
+		},
+	}
+
+	resp, err := v.githubInstallation.AccessToken(ctx, tr)
+	if err != nil {
+		return "", fmt.Errorf("failed to get access token: %w", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v55/github"
+
+	"github.com/abcxyz/pkg/testutil"
+)
+
+func TestMatchIssue_LinkedPullRequests(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		response    string
+		want        []string
+		wantErr     string
+		wantInvalid bool
+	}{
+		{
+			name: "linked",
+			response: `{"data": {"repository": {"issue": {"closedByPullRequestsReferences": {"nodes": [
+				{"url": "https://github.com/test-owner/test-repo/pull/7", "state": "OPEN"},
+				{"url": "https://github.com/test-owner/test-repo/pull/8", "state": "CLOSED"},
+				{"url": "https://github.com/test-owner/test-repo/pull/9", "state": "MERGED"}
+			]}}}}}`,
+			want: []string{
+				"https://github.com/test-owner/test-repo/pull/7",
+				"https://github.com/test-owner/test-repo/pull/9",
+			},
+		},
+		{
+			name: "only_closed",
+			response: `{"data": {"repository": {"issue": {"closedByPullRequestsReferences": {"nodes": [
+				{"url": "https://github.com/test-owner/test-repo/pull/8", "state": "CLOSED"}
+			]}}}}}`,
+			wantErr:     "issue has no linked open or merged pull request",
+			wantInvalid: true,
+		},
+		{
+			name: "inaccessible_pull_request",
+			response: `{"data": {"repository": {"issue": {"closedByPullRequestsReferences": {"nodes": [
+				null,
+				{"url": "https://github.com/test-owner/test-repo/pull/9", "state": "MERGED"}
+			]}}}}, "errors": [{"type": "FORBIDDEN", "message": "Resource not accessible by integration",
+				"path": ["repository", "issue", "closedByPullRequestsReferences", "nodes", 0]}]}`,
+			want: []string{"https://github.com/test-owner/test-repo/pull/9"},
+		},
+		{
+			name: "only_inaccessible_pull_request",
+			response: `{"data": {"repository": {"issue": {"closedByPullRequestsReferences": {"nodes": [null]}}}}, "errors": [
+				{"type": "FORBIDDEN", "message": "Resource not accessible by integration",
+				"path": ["repository", "issue", "closedByPullRequestsReferences", "nodes", 0]}]}`,
+			wantErr:     "issue has no linked open or merged pull request",
+			wantInvalid: true,
+		},
+		{
+			name: "partial_error_outside_nodes",
+			response: `{"data": {"repository": {"issue": null}}, "errors": [
+				{"type": "INTERNAL", "message": "boom", "path": ["repository", "issue"]}]}`,
+			wantErr: "failed to get linked pull requests: graphql: boom",
+		},
+		{
+			name:     "graphql_error",
+			response: `{"errors": [{"type": "INTERNAL", "message": "boom"}]}`,
+			wantErr:  "failed to get linked pull requests",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			installation := testGitHubInstallation(t, http.StatusCreated)
+			hc := newTestServer(t, testHandleIssueAndGraphQL(t, []byte(`{"state": "open"}`), tc.response))
+
+			v := NewValidator(github.NewClient(hc), installation, &Policy{IssueRequireLinkedPullRequest: true})
+			got, err := v.MatchIssue(t.Context(), testGitHubIssueURL)
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if got, want := errors.Is(err, errInvalidJustification), tc.wantInvalid; got != want {
+				t.Errorf("errors.Is(%v, errInvalidJustification) = %t, want %t", err, got, want)
+			}
+			if diff := cmp.Diff(tc.want, got.LinkedPullRequests); diff != "" {
+				t.Errorf("LinkedPullRequests unexpected diff (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMatchPullRequest_LinkedIssues(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		response    string
+		want        []string
+		wantErr     string
+		wantInvalid bool
+	}{
+		{
+			name: "linked",
+			response: `{"data": {"repository": {"pullRequest": {"closingIssuesReferences": {"nodes": [
+				{"url": "https://github.com/test-owner/test-repo/issues/1"}
+			]}}}}}`,
+			want: []string{"https://github.com/test-owner/test-repo/issues/1"},
+		},
+		{
+			name: "inaccessible_issue",
+			response: `{"data": {"repository": {"pullRequest": {"closingIssuesReferences": {"nodes": [
+				{"url": "https://github.com/test-owner/test-repo/issues/1"},
+				null
+			]}}}}, "errors": [{"type": "NOT_FOUND", "message": "Could not resolve to an Issue",
+				"path": ["repository", "pullRequest", "closingIssuesReferences", "nodes", 1]}]}`,
+			want: []string{"https://github.com/test-owner/test-repo/issues/1"},
+		},
+		{
+			name:        "not_linked",
+			response:    `{"data": {"repository": {"pullRequest": {"closingIssuesReferences": {"nodes": []}}}}}`,
+			wantErr:     "pull request doesn't close any issue",
+			wantInvalid: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			installation := testGitHubInstallation(t, http.StatusCreated)
+			pullRequestHandler := testHandlePullRequests(t, `{"number": 7, "state": "open"}`, "")
+			graphQLHandler := testHandleGraphQL(t, func(vars map[string]any) string {
+				return tc.response
+			})
+			hc := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/graphql" {
+					graphQLHandler(w, r)
+					return
+				}
+				pullRequestHandler(w, r)
+			})
+
+			v := NewValidator(github.NewClient(hc), installation, &Policy{PullRequestRequireLinkedIssue: true})
+			got, err := v.MatchPullRequest(t.Context(), "https://github.com/test-owner/test-repo/pull/7")
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if got, want := errors.Is(err, errInvalidJustification), tc.wantInvalid; got != want {
+				t.Errorf("errors.Is(%v, errInvalidJustification) = %t, want %t", err, got, want)
+			}
+			if diff := cmp.Diff(tc.want, got.LinkedIssues); diff != "" {
+				t.Errorf("LinkedIssues unexpected diff (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
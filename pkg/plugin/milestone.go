@@ -0,0 +1,58 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+// validateIssueMilestones verifies the issue milestone is one of the allowed
+// milestones, if any.
+func (v *Validator) validateIssueMilestones(issue *github.Issue) error {
+	allowed := v.policy.IssueMilestones
+	if len(allowed) == 0 {
+		return nil
+	}
+	m := issue.GetMilestone()
+	if m == nil {
+		return violationf(ruleIssueMilestones, "issue has no milestone")
+	}
+	if !slices.ContainsFunc(allowed, func(t string) bool { return strings.EqualFold(t, m.GetTitle()) }) {
+		return violationf(ruleIssueMilestones, "issue milestone %q is not one of %q", m.GetTitle(), allowed)
+	}
+	return nil
+}
+
+// validateIssueOpenMilestone verifies the issue milestone is open and not past
+// due, if required.
+func (v *Validator) validateIssueOpenMilestone(issue *github.Issue) error {
+	if !v.policy.IssueRequireOpenMilestone {
+		return nil
+	}
+	m := issue.GetMilestone()
+	switch {
+	case m == nil:
+		return violationf(ruleIssueRequireOpenMilestone, "issue has no milestone")
+	case m.GetState() != "open":
+		return violationf(ruleIssueRequireOpenMilestone, "issue milestone %q is closed", m.GetTitle())
+	case m.DueOn != nil && m.GetDueOn().Before(time.Now()):
+		return violationf(ruleIssueRequireOpenMilestone, "issue milestone %q was due on %s", m.GetTitle(), m.GetDueOn().Format(time.DateOnly))
+	}
+	return nil
+}
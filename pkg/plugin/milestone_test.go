@@ -0,0 +1,113 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/abcxyz/pkg/testutil"
+)
+
+func TestValidateIssueMilestone(t *testing.T) {
+	t.Parallel()
+
+	milestone := func(title, state string, dueOn time.Time) *github.Milestone {
+		m := &github.Milestone{Title: github.String(title), State: github.String(state)}
+		if !dueOn.IsZero() {
+			m.DueOn = &github.Timestamp{Time: dueOn}
+		}
+		return m
+	}
+
+	cases := []struct {
+		name       string
+		policy     *Policy
+		milestone  *github.Milestone
+		wantErr    string
+		wantReason Reason
+	}{
+		{
+			name:   "no_policy",
+			policy: &Policy{},
+		},
+		{
+			name:       "missing",
+			policy:     &Policy{IssueMilestones: []string{"Q3 Incidents"}},
+			wantErr:    "issue has no milestone",
+			wantReason: ReasonMilestoneNotAllowed,
+		},
+		{
+			name:      "allowed",
+			policy:    &Policy{IssueMilestones: []string{"q3 incidents"}},
+			milestone: milestone("Q3 Incidents", "closed", time.Time{}),
+		},
+		{
+			name:       "not_allowed",
+			policy:     &Policy{IssueMilestones: []string{"Q3 Incidents"}},
+			milestone:  milestone("Backlog", "open", time.Time{}),
+			wantErr:    `issue milestone "Backlog" is not one of ["Q3 Incidents"]`,
+			wantReason: ReasonMilestoneNotAllowed,
+		},
+		{
+			name:      "open",
+			policy:    &Policy{IssueRequireOpenMilestone: true},
+			milestone: milestone("v2", "open", time.Now().Add(24*time.Hour)),
+		},
+		{
+			name:       "closed",
+			policy:     &Policy{IssueRequireOpenMilestone: true},
+			milestone:  milestone("v1", "closed", time.Time{}),
+			wantErr:    `issue milestone "v1" is closed`,
+			wantReason: ReasonMilestoneNotAllowed,
+		},
+		{
+			name:       "past_due",
+			policy:     &Policy{IssueRequireOpenMilestone: true},
+			milestone:  milestone("v1", "open", time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)),
+			wantErr:    `issue milestone "v1" was due on 2020-01-02`,
+			wantReason: ReasonMilestoneNotAllowed,
+		},
+		{
+			name: "not_allowed_and_closed",
+			policy: &Policy{
+				IssueMilestones:           []string{"v2"},
+				IssueRequireOpenMilestone: true,
+			},
+			milestone:  milestone("v1", "closed", time.Time{}),
+			wantErr:    `issue milestone "v1" is not one of ["v2"]`,
+			wantReason: ReasonMilestoneNotAllowed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			v := NewValidator(nil, nil, tc.policy)
+			issue := &github.Issue{Milestone: tc.milestone}
+			err := errors.Join(v.validateIssueMilestones(issue), v.validateIssueOpenMilestone(issue))
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if got, want := testReason(err), tc.wantReason; got != want {
+				t.Errorf("reason of %v got %q, want %q", err, got, want)
+			}
+		})
+	}
+}
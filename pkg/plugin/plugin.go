@@ -18,86 +18,553 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/go-github/v55/github"
+	"golang.org/x/text/language"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	jvspb "github.com/abcxyz/jvs/apis/v0"
 	"github.com/abcxyz/pkg/githubauth"
+	"github.com/abcxyz/pkg/logging"
 )
 
 const (
-	// githubCategory is the justification category this plugin will be validating.
+	// githubCategory is the default justification category this plugin will be
+	// validating, configured by the plugin config.
 	githubCategory               = "github"
 	respAnnotationKeyIssueURL    = "github_issue_url"
 	respAnnotationKeyIssueOwner  = "github_issue_owner"
 	respAnnotationKeyIssueRepo   = "github_issue_repo"
 	respAnnotationKeyIssueNumber = "github_issue_number"
+	// respAnnotationKeyIssueSnapshotHash records the issue content at
+	// validation time so it can be checked for later edits.
+	respAnnotationKeyIssueSnapshotHash = "github_issue_snapshot_hash"
+
+	// respAnnotationKeyRepository is the repository of the reference in the
+	// "owner/repo" format, and respAnnotationKeyRepositoryTopics its comma
+	// separated topics.
+	respAnnotationKeyRepository           = "github_repository"
+	respAnnotationKeyRepositoryVisibility = "github_repository_visibility"
+	respAnnotationKeyRepositoryArchived   = "github_repository_archived"
+	respAnnotationKeyRepositoryFork       = "github_repository_fork"
+	respAnnotationKeyRepositoryTopics     = "github_repository_topics"
+	// respAnnotationKeyRepositoryPropertyPrefix is followed by the name of
+	// each custom property, with comma separated values.
+	respAnnotationKeyRepositoryPropertyPrefix = "github_repository_property_"
+
+	respAnnotationKeyProject       = "github_project"
+	respAnnotationKeyProjectStatus = "github_project_status"
+
+	respAnnotationKeyDiscussionURL      = "github_discussion_url"
+	respAnnotationKeyDiscussionOwner    = "github_discussion_owner"
+	respAnnotationKeyDiscussionRepo     = "github_discussion_repo"
+	respAnnotationKeyDiscussionNumber   = "github_discussion_number"
+	respAnnotationKeyDiscussionCategory = "github_discussion_category"
+	respAnnotationKeyDiscussionAnswered = "github_discussion_answered"
+
+	respAnnotationKeyWorkflowRunURL     = "github_workflow_run_url"
+	respAnnotationKeyWorkflowRunOwner   = "github_workflow_run_owner"
+	respAnnotationKeyWorkflowRunRepo    = "github_workflow_run_repo"
+	respAnnotationKeyWorkflowRunID      = "github_workflow_run_id"
+	respAnnotationKeyWorkflowName       = "github_workflow_name"
+	respAnnotationKeyWorkflowRunHeadSHA = "github_workflow_run_head_sha"
+	respAnnotationKeyWorkflowRunActor   = "github_workflow_run_actor"
+
+	respAnnotationKeyDeploymentURL         = "github_deployment_url"
+	respAnnotationKeyDeploymentOwner       = "github_deployment_owner"
+	respAnnotationKeyDeploymentRepo        = "github_deployment_repo"
+	respAnnotationKeyDeploymentID          = "github_deployment_id"
+	respAnnotationKeyDeploymentEnvironment = "github_deployment_environment"
+	respAnnotationKeyDeploymentState       = "github_deployment_state"
+
+	respAnnotationKeySecurityURL      = "github_security_url"
+	respAnnotationKeySecurityOwner    = "github_security_owner"
+	respAnnotationKeySecurityRepo     = "github_security_repo"
+	respAnnotationKeySecurityKind     = "github_security_kind"
+	respAnnotationKeySecurityID       = "github_security_id"
+	respAnnotationKeySecurityState    = "github_security_state"
+	respAnnotationKeySecuritySeverity = "github_security_severity"
+
+	respAnnotationKeyPullRequestURL    = "github_pull_request_url"
+	respAnnotationKeyPullRequestOwner  = "github_pull_request_owner"
+	respAnnotationKeyPullRequestRepo   = "github_pull_request_repo"
+	respAnnotationKeyPullRequestNumber = "github_pull_request_number"
+	respAnnotationKeyPullRequestState  = "github_pull_request_state"
+	respAnnotationKeyCommitSHA         = "github_commit_sha"
+
+	// respAnnotationKeyLinkedPullRequests and respAnnotationKeyLinkedIssues
+	// are comma separated URLs, only set when links are required.
+	respAnnotationKeyLinkedPullRequests = "github_linked_pull_requests"
+	respAnnotationKeyLinkedIssues       = "github_linked_issues"
+
+	respAnnotationKeyIssueMilestone  = "github_issue_milestone"
+	respAnnotationKeyIssueType       = "github_issue_type"
+	respAnnotationKeyIssueAuthor     = "github_issue_author"
+	respAnnotationKeyIssueAssignee   = "github_issue_assignee"
+	respAnnotationKeyIssueApprover   = "github_issue_approver"
+	respAnnotationKeyIssueApprovedAt = "github_issue_approved_at"
+
+	// respAnnotationKeyReason is the comma separated reasons of an invalid
+	// justification.
+	respAnnotationKeyReason = "github_reason"
+	// respAnnotationKeyPolicyWarnings is the comma separated reasons of the
+	// policy violations which were not enforced, and
+	// respAnnotationKeyPolicyWarningPrefix is followed by the index of each
+	// violation, with its error message.
+	respAnnotationKeyPolicyWarnings      = "github_policy_warnings"
+	respAnnotationKeyPolicyWarningPrefix = "github_policy_warning_"
+
+	// reqAnnotationKeyRequester and reqAnnotationKeyAudience are the optional
+	// justification annotations describing who requested access and for which
+	// audience. They are set by the client and not verified, so they are only
+	// used for the audit comment.
+	reqAnnotationKeyRequester = "requester"
+	reqAnnotationKeyAudience  = "audience"
 )
 
-// issueMatcher is the mockable interface for the convenience of testing.
-type issueMatcher interface {
+// referenceMatcher is the mockable interface for the convenience of testing.
+type referenceMatcher interface {
 	MatchIssue(ctx context.Context, issueURL string) (*pluginGitHubIssue, error)
+	MatchDiscussion(ctx context.Context, discussionURL string) (*pluginGitHubDiscussion, error)
+	MatchProjectItem(ctx context.Context, itemURL string) (*pluginGitHubIssue, error)
+	MatchWorkflowRun(ctx context.Context, runURL string) (*pluginGitHubWorkflowRun, error)
+	MatchDeployment(ctx context.Context, deploymentURL string) (*pluginGitHubDeployment, error)
+	MatchSecurityReference(ctx context.Context, securityURL string) (*pluginGitHubSecurityReference, error)
+	MatchPullRequest(ctx context.Context, pullRequestURL string) (*pluginGitHubPullRequest, error)
+	MatchCommit(ctx context.Context, commitURL string) (*pluginGitHubPullRequest, error)
+	MatchRepository(ctx context.Context, owner, repoName string) (*pluginGitHubRepository, error)
+}
+
+// issueWriter is the mockable interface for writing back to validated issues.
+type issueWriter interface {
+	WriteBack(ctx context.Context, info *pluginGitHubIssue, entry *auditEntry) error
 }
 
 // GitHubPlugin is the implementation of jvspb.Validator interface.
 //
 // See: https://pkg.go.dev/github.com/abcxyz/jvs@v0.1.4/apis/v0#Validator
 type GitHubPlugin struct {
-	// validator implements issueMatcher for validating github issues.
-	validator issueMatcher
+	// categories are the categories validated by the plugin, by name.
+	categories map[string]*pluginCategory
+	// category is the category served to the web UI.
+	category string
+	// suggestOwners are the organizations and users whose issues are
+	// suggested.
+	suggestOwners []string
+	// writer writes back to validated issues, it is nil when write-back is
+	// disabled.
+	writer issueWriter
+	// writeBacks tracks the in-flight asynchronous write-backs.
+	writeBacks sync.WaitGroup
+	// now returns the current time, it is overridden in tests.
+	now func() time.Time
+}
+
+// pluginCategory is a justification category validated by the plugin.
+type pluginCategory struct {
+	// validator implements referenceMatcher for validating github issues,
+	// discussions, project items, workflow runs, deployments, security
+	// advisories and alerts, pull requests and commits with the category
+	// policy.
+	validator referenceMatcher
+	// suggester suggests issues passing validation with the category policy.
+	suggester issueSuggester
+	// referenceMode and maxReferences are the policy for justifications with
+	// several references. All references must be valid when referenceMode is
+	// empty, and there is no limit when maxReferences is zero.
+	referenceMode string
+	maxReferences int
+	// degraded accepts references while GitHub is unreachable, it is nil when
+	// degraded mode is disabled.
+	degraded *degradedFallback
+	// repositoryPolicy is whether the category policy restricts repositories,
+	// so the repository of each reference is looked up.
+	repositoryPolicy bool
 	// uiData contains the data for ui to display
 	uiData *jvspb.UIData
+	// localized are the localized variants of uiData, matched by matcher
+	// after its fallback tag. matcher is nil when there are none.
+	localized []*jvspb.UIData
+	matcher   language.Matcher
 }
 
 // NewGitHubPlugin creates a new GitHubPlugin.
-func NewGitHubPlugin(ctx context.Context, ghClient *github.Client, ghInstall *githubauth.AppInstallation, cfg *PluginConfig) *GitHubPlugin {
-	return &GitHubPlugin{
-		validator: NewValidator(ghClient, ghInstall),
-		uiData: &jvspb.UIData{
-			DisplayName: cfg.GitHubPluginDisplayName,
-			Hint:        cfg.GitHubPluginHint,
-		},
+func NewGitHubPlugin(ctx context.Context, ghClient *github.Client, ghInstall *githubauth.AppInstallation, cfg *PluginConfig) (*GitHubPlugin, error) {
+	p := &GitHubPlugin{
+		categories:    make(map[string]*pluginCategory, len(cfg.Categories)+1),
+		category:      cfg.GitHubPluginCategory,
+		suggestOwners: cfg.GitHubSuggestOwners,
+		now:           time.Now,
 	}
+	if p.category == "" {
+		p.category = githubCategory
+	}
+
+	v := NewValidator(ghClient, ghInstall, &cfg.Policy)
+	v.SetUsedLabel(cfg.GitHubUsedLabel)
+	c, err := newPluginCategory(v,
+		&uiTemplateData{Category: githubCategory, ExampleURL: cfg.GitHubPluginExampleURL, Policy: &cfg.Policy},
+		&LocalizedText{DisplayName: cfg.GitHubPluginDisplayName, Hint: cfg.GitHubPluginHint},
+		cfg.Localized, cfg.GitHubPluginUITemplates)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ui data of category %q: %w", githubCategory, err)
+	}
+	c.suggester = v
+	c.referenceMode, c.maxReferences = cfg.Policy.ReferenceMode, cfg.Policy.MaxReferences
+	c.degraded = newDegradedFallback(&cfg.Policy)
+	c.repositoryPolicy = cfg.Policy.hasRepositoryPolicy()
+	p.categories[githubCategory] = c
+
+	for _, cc := range cfg.Categories {
+		v := NewValidator(ghClient, ghInstall, &cc.Policy)
+		v.SetUsedLabel(cfg.GitHubUsedLabel)
+		c, err := newPluginCategory(v,
+			&uiTemplateData{Category: cc.Name, ExampleURL: cc.ExampleURL, Policy: &cc.Policy},
+			&LocalizedText{DisplayName: cc.DisplayName, Hint: cc.Hint},
+			cc.Localized, cfg.GitHubPluginUITemplates)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ui data of category %q: %w", cc.Name, err)
+		}
+		c.suggester = v
+		c.referenceMode, c.maxReferences = cc.Policy.ReferenceMode, cc.Policy.MaxReferences
+		c.degraded = newDegradedFallback(&cc.Policy)
+		c.repositoryPolicy = cc.Policy.hasRepositoryPolicy()
+		p.categories[cc.Name] = c
+	}
+
+	for name, c := range p.categories {
+		if c.matcher != nil {
+			logging.FromContext(ctx).WarnContext(ctx, "localized display names and hints are not served to JVS, "+
+				"which doesn't send the accept-language metadata",
+				"category", name)
+		}
+	}
+
+	// Avoid storing a typed nil in the interface.
+	if w := NewIssueWriter(ghClient, ghInstall, cfg); w != nil {
+		p.writer = w
+	}
+	return p, nil
 }
 
 // Validate returns the validation result.
 func (g *GitHubPlugin) Validate(ctx context.Context, req *jvspb.ValidateJustificationRequest) (*jvspb.ValidateJustificationResponse, error) {
-	if got, want := req.GetJustification().GetCategory(), githubCategory; got != want {
-		return generateInvalidErrResq(fmt.Sprintf("failed to perform validation, expected category %q to be %q", got, want)), nil
+	category, ok := g.categories[req.GetJustification().GetCategory()]
+	if !ok {
+		return generateInvalidErrResq(ReasonCategoryUnknown, fmt.Sprintf("failed to perform validation, expected category %q to be one of %q",
+			req.GetJustification().GetCategory(), slices.Sorted(maps.Keys(g.categories)))), nil
 	}
 
-	info, err := g.validator.MatchIssue(ctx, req.GetJustification().GetValue())
+	j := req.GetJustification()
+	refs := parseReferences(j.GetValue())
+	if len(refs) > 1 {
+		return g.validateReferences(ctx, category, j, refs)
+	}
+	// Validate the parsed reference, without separators or duplicates, e.g.
+	// "url," or "url url". Empty values are validated as is to report them.
+	ref := strings.TrimSpace(j.GetValue())
+	if len(refs) == 1 {
+		ref = refs[0]
+	}
+
+	result, err := g.matchReferenceWithFallback(ctx, category, j, ref)
 	if err != nil {
 		if errors.Is(err, errInvalidJustification) {
-			return generateInvalidErrResq(err.Error()), nil
+			return generateInvalidErrResq(reasonOf(err), err.Error()), nil
 		} else {
-			return nil, status.Error(codes.Internal, err.Error())
+			return nil, validationStatusError(err)
 		}
 	}
+	g.writeBack(ctx, j, result)
+
+	var warnings []string
+	for _, w := range result.warnings {
+		warnings = append(warnings, formatReason(reasonOf(w), w.Error()))
+	}
 	return &jvspb.ValidateJustificationResponse{
-		Valid: true,
-		Annotation: map[string]string{
-			respAnnotationKeyIssueURL:    req.GetJustification().GetValue(),
-			respAnnotationKeyIssueOwner:  info.Owner,
-			respAnnotationKeyIssueRepo:   info.RepoName,
-			respAnnotationKeyIssueNumber: strconv.Itoa(info.IssueNumber),
-		},
+		Valid:      true,
+		Warning:    warnings,
+		Annotation: result.annotation,
 	}, nil
 }
 
-// GetUIData returns UIData for jvs ui service to use.
+// matchReference validates the GitHub object referenced by ref and, when the
+// category restricts repositories, its repository, and returns the response
+// annotations describing them.
+func (g *GitHubPlugin) matchReference(ctx context.Context, category *pluginCategory, j *jvspb.Justification, ref string) (*referenceResult, error) {
+	ctx, warnings := withPolicyWarnings(ctx)
+
+	// Validate the reference first, so malformed references are reported
+	// before any repository lookup.
+	result, err := g.matchReferenceKind(ctx, category.validator, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if owner, repoName, ok := repositoryFromURL(ref); ok && category.repositoryPolicy {
+		info, err := category.validator.MatchRepository(ctx, owner, repoName)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // Want passthrough
+		}
+		maps.Copy(result.annotation, repositoryAnnotations(info))
+	}
+
+	// Report the policy violations which were not enforced.
+	logger := logging.FromContext(ctx)
+	result.warnings = warnings.list()
+	for i, w := range result.warnings {
+		reason := reasonOf(w)
+		logger.WarnContext(ctx, "policy violation not enforced",
+			"category", j.GetCategory(),
+			"reference", ref,
+			"reason", reason,
+			"error", w)
+		result.annotation[respAnnotationKeyPolicyWarningPrefix+strconv.Itoa(i)] = formatReason(reason, w.Error())
+		if reasons := result.annotation[respAnnotationKeyPolicyWarnings]; reasons == "" {
+			result.annotation[respAnnotationKeyPolicyWarnings] = string(reason)
+		} else if !slices.Contains(strings.Split(reasons, ","), string(reason)) {
+			result.annotation[respAnnotationKeyPolicyWarnings] = reasons + "," + string(reason)
+		}
+	}
+	return result, nil
+}
+
+// matchReferenceKind validates the reference according to its kind and returns
+// the response annotations describing it.
+func (g *GitHubPlugin) matchReferenceKind(ctx context.Context, validator referenceMatcher, ref string) (*referenceResult, error) {
+	switch referenceKindFromURL(ref) {
+	case referenceKindDiscussion:
+		info, err := validator.MatchDiscussion(ctx, ref)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // Want passthrough
+		}
+		return &referenceResult{annotation: map[string]string{
+			respAnnotationKeyDiscussionURL:      ref,
+			respAnnotationKeyDiscussionOwner:    info.Owner,
+			respAnnotationKeyDiscussionRepo:     info.RepoName,
+			respAnnotationKeyDiscussionNumber:   strconv.Itoa(info.DiscussionNumber),
+			respAnnotationKeyDiscussionCategory: info.Category,
+			respAnnotationKeyDiscussionAnswered: strconv.FormatBool(info.Answered),
+		}}, nil
+	case referenceKindWorkflowRun:
+		info, err := validator.MatchWorkflowRun(ctx, ref)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // Want passthrough
+		}
+		return &referenceResult{annotation: map[string]string{
+			respAnnotationKeyWorkflowRunURL:     ref,
+			respAnnotationKeyWorkflowRunOwner:   info.Owner,
+			respAnnotationKeyWorkflowRunRepo:    info.RepoName,
+			respAnnotationKeyWorkflowRunID:      strconv.FormatInt(info.RunID, 10),
+			respAnnotationKeyWorkflowName:       info.WorkflowName,
+			respAnnotationKeyWorkflowRunHeadSHA: info.HeadSHA,
+			respAnnotationKeyWorkflowRunActor:   info.Actor,
+		}}, nil
+	case referenceKindDeployment:
+		info, err := validator.MatchDeployment(ctx, ref)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // Want passthrough
+		}
+		annotation := map[string]string{
+			respAnnotationKeyDeploymentURL:         ref,
+			respAnnotationKeyDeploymentOwner:       info.Owner,
+			respAnnotationKeyDeploymentRepo:        info.RepoName,
+			respAnnotationKeyDeploymentEnvironment: info.Environment,
+			respAnnotationKeyDeploymentState:       info.State,
+		}
+		if info.DeploymentID != 0 {
+			annotation[respAnnotationKeyDeploymentID] = strconv.FormatInt(info.DeploymentID, 10)
+		}
+		if info.RunID != 0 {
+			annotation[respAnnotationKeyWorkflowRunID] = strconv.FormatInt(info.RunID, 10)
+		}
+		return &referenceResult{annotation: annotation}, nil
+	case referenceKindSecurity:
+		info, err := validator.MatchSecurityReference(ctx, ref)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // Want passthrough
+		}
+		return &referenceResult{annotation: map[string]string{
+			respAnnotationKeySecurityURL:      ref,
+			respAnnotationKeySecurityOwner:    info.Owner,
+			respAnnotationKeySecurityRepo:     info.RepoName,
+			respAnnotationKeySecurityKind:     info.Kind,
+			respAnnotationKeySecurityID:       info.ID,
+			respAnnotationKeySecurityState:    info.State,
+			respAnnotationKeySecuritySeverity: info.Severity,
+		}}, nil
+	case referenceKindPullRequest:
+		info, err := validator.MatchPullRequest(ctx, ref)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // Want passthrough
+		}
+		return &referenceResult{annotation: pullRequestAnnotations(info)}, nil
+	case referenceKindCommit:
+		info, err := validator.MatchCommit(ctx, ref)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // Want passthrough
+		}
+		annotation := pullRequestAnnotations(info)
+		annotation[respAnnotationKeyCommitSHA] = info.CommitSHA
+		return &referenceResult{annotation: annotation}, nil
+	case referenceKindProjectItem:
+		info, err := validator.MatchProjectItem(ctx, ref)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // Want passthrough
+		}
+		// Record the canonical issue URL, so the issue can be looked up
+		// from the annotations.
+		return &referenceResult{annotation: issueAnnotations(info, info.URL()), issue: info}, nil
+	default:
+		info, err := validator.MatchIssue(ctx, ref)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // Want passthrough
+		}
+		return &referenceResult{annotation: issueAnnotations(info, ref), issue: info}, nil
+	}
+}
+
+// issueAnnotations returns the response annotations describing the validated
+// issue.
+func issueAnnotations(info *pluginGitHubIssue, issueURL string) map[string]string {
+	annotation := map[string]string{
+		respAnnotationKeyIssueURL:    issueURL,
+		respAnnotationKeyIssueOwner:  info.Owner,
+		respAnnotationKeyIssueRepo:   info.RepoName,
+		respAnnotationKeyIssueNumber: strconv.Itoa(info.IssueNumber),
+
+		respAnnotationKeyIssueSnapshotHash: info.SnapshotHash,
+	}
+	if info.Project != "" {
+		annotation[respAnnotationKeyProject] = info.Project
+		annotation[respAnnotationKeyProjectStatus] = info.ProjectStatus
+	}
+	if len(info.LinkedPullRequests) > 0 {
+		annotation[respAnnotationKeyLinkedPullRequests] = strings.Join(info.LinkedPullRequests, ",")
+	}
+	if info.Milestone != "" {
+		annotation[respAnnotationKeyIssueMilestone] = info.Milestone
+	}
+	if info.Type != "" {
+		annotation[respAnnotationKeyIssueType] = info.Type
+	}
+	if info.Author != "" {
+		annotation[respAnnotationKeyIssueAuthor] = info.Author
+	}
+	if info.Assignee != "" {
+		annotation[respAnnotationKeyIssueAssignee] = info.Assignee
+	}
+	if info.Approver != "" {
+		annotation[respAnnotationKeyIssueApprover] = info.Approver
+		annotation[respAnnotationKeyIssueApprovedAt] = info.ApprovedAt.UTC().Format(time.RFC3339)
+	}
+	return annotation
+}
+
+// pullRequestAnnotations returns the response annotations describing the
+// validated pull request.
+func pullRequestAnnotations(info *pluginGitHubPullRequest) map[string]string {
+	annotation := map[string]string{
+		respAnnotationKeyPullRequestURL:    info.URL(),
+		respAnnotationKeyPullRequestOwner:  info.Owner,
+		respAnnotationKeyPullRequestRepo:   info.RepoName,
+		respAnnotationKeyPullRequestNumber: strconv.Itoa(info.PullNumber),
+		respAnnotationKeyPullRequestState:  info.State,
+	}
+	if len(info.LinkedIssues) > 0 {
+		annotation[respAnnotationKeyLinkedIssues] = strings.Join(info.LinkedIssues, ",")
+	}
+	return annotation
+}
+
+// writeBack starts the write-back to the issue of the validated reference, if
+// any and enabled.
+func (g *GitHubPlugin) writeBack(ctx context.Context, j *jvspb.Justification, result *referenceResult) {
+	if g.writer == nil || result.issue == nil {
+		return
+	}
+	g.writeBackAsync(ctx, result.issue, &auditEntry{
+		Time:      g.now(),
+		Requester: j.GetAnnotation()[reqAnnotationKeyRequester],
+		Audience:  j.GetAnnotation()[reqAnnotationKeyAudience],
+	})
+}
+
+// writeBackAsync performs the write-back in the background, so it never delays
+// or fails the validation. Failures are only logged.
+func (g *GitHubPlugin) writeBackAsync(ctx context.Context, info *pluginGitHubIssue, entry *auditEntry) {
+	logger := logging.FromContext(ctx)
+
+	// The write-back must outlive the validation request.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), writeBackTimeout)
+
+	g.writeBacks.Add(1)
+	go func() {
+		defer g.writeBacks.Done()
+		defer cancel()
+
+		if err := g.writer.WriteBack(ctx, info, entry); err != nil {
+			logger.ErrorContext(ctx, "failed to write back to issue",
+				"owner", info.Owner,
+				"repo", info.RepoName,
+				"issue_number", info.IssueNumber,
+				"error", err)
+		}
+	}()
+}
+
+// Close waits for the in-flight write-backs to finish, or until ctx is done.
+func (g *GitHubPlugin) Close(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		g.writeBacks.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to wait for write-backs: %w", ctx.Err())
+	}
+}
+
+// GetUIData returns UIData for jvs ui service to use. The request doesn't
+// include the category, so the UIData of the served category is returned, in
+// the language best matching the "accept-language" gRPC metadata if localized.
+// JVS doesn't send the metadata yet, so the default UIData is returned to it.
 func (g *GitHubPlugin) GetUIData(ctx context.Context, req *jvspb.GetUIDataRequest) (*jvspb.UIData, error) {
-	return g.uiData, nil
+	category, ok := g.categories[g.category]
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "category %q is not configured", g.category)
+	}
+	return category.uiDataFor(ctx), nil
+}
+
+// validationStatusError returns the gRPC error of a validation which failed
+// other than for an invalid justification, Unavailable when GitHub API calls
+// are rejected by the circuit breaker and Internal otherwise.
+func validationStatusError(err error) error {
+	if errors.Is(err, errCircuitOpen) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
 }
 
 // generateInvalidErrResq generates a ValidateJustificationResponse indicating
-// the justification is invalid, and use the provided string to set Error field.
-func generateInvalidErrResq(s string) *jvspb.ValidateJustificationResponse {
+// the justification is invalid, and use the provided string prefixed with the
+// reason to set Error field. The reason is also set as annotation.
+func generateInvalidErrResq(reason Reason, s string) *jvspb.ValidateJustificationResponse {
 	return &jvspb.ValidateJustificationResponse{
-		Valid: false,
-		Error: []string{s},
+		Valid:      false,
+		Error:      []string{formatReason(reason, s)},
+		Annotation: map[string]string{respAnnotationKeyReason: string(reason)},
 	}
 }
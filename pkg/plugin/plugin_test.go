@@ -18,7 +18,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -28,35 +30,94 @@ import (
 )
 
 const (
-	testGitHubIssueURL = "https://github.com/test-owner/test-repo/issues/1"
+	testGitHubIssueURL      = "https://github.com/test-owner/test-repo/issues/1"
+	testGitHubDiscussionURL = "https://github.com/test-owner/test-repo/discussions/3"
 )
 
-type testIssueMatcher struct {
-	rPluginGitHubIssue *pluginGitHubIssue
-	rErr               error
+type testReferenceMatcher struct {
+	rPluginGitHubIssue       *pluginGitHubIssue
+	rPluginGitHubDiscussion  *pluginGitHubDiscussion
+	rPluginGitHubWorkflowRun *pluginGitHubWorkflowRun
+	rPluginGitHubDeployment  *pluginGitHubDeployment
+	rPluginGitHubSecurity    *pluginGitHubSecurityReference
+	rPluginGitHubPullRequest *pluginGitHubPullRequest
+	rPluginGitHubRepository  *pluginGitHubRepository
+	rErr                     error
+	rRepositoryErr           error
 }
 
-func (t *testIssueMatcher) MatchIssue(ctx context.Context, issueURL string) (*pluginGitHubIssue, error) {
+func (t *testReferenceMatcher) MatchIssue(ctx context.Context, issueURL string) (*pluginGitHubIssue, error) {
 	return t.rPluginGitHubIssue, t.rErr
 }
 
+func (t *testReferenceMatcher) MatchDiscussion(ctx context.Context, discussionURL string) (*pluginGitHubDiscussion, error) {
+	return t.rPluginGitHubDiscussion, t.rErr
+}
+
+func (t *testReferenceMatcher) MatchProjectItem(ctx context.Context, itemURL string) (*pluginGitHubIssue, error) {
+	return t.rPluginGitHubIssue, t.rErr
+}
+
+func (t *testReferenceMatcher) MatchWorkflowRun(ctx context.Context, runURL string) (*pluginGitHubWorkflowRun, error) {
+	return t.rPluginGitHubWorkflowRun, t.rErr
+}
+
+func (t *testReferenceMatcher) MatchDeployment(ctx context.Context, deploymentURL string) (*pluginGitHubDeployment, error) {
+	return t.rPluginGitHubDeployment, t.rErr
+}
+
+func (t *testReferenceMatcher) MatchSecurityReference(ctx context.Context, securityURL string) (*pluginGitHubSecurityReference, error) {
+	return t.rPluginGitHubSecurity, t.rErr
+}
+
+func (t *testReferenceMatcher) MatchPullRequest(ctx context.Context, pullRequestURL string) (*pluginGitHubPullRequest, error) {
+	return t.rPluginGitHubPullRequest, t.rErr
+}
+
+func (t *testReferenceMatcher) MatchCommit(ctx context.Context, commitURL string) (*pluginGitHubPullRequest, error) {
+	return t.rPluginGitHubPullRequest, t.rErr
+}
+
+// MatchRepository returns a private repository with the given names, unless
+// the repository or error are set.
+func (t *testReferenceMatcher) MatchRepository(ctx context.Context, owner, repoName string) (*pluginGitHubRepository, error) {
+	if t.rPluginGitHubRepository != nil || t.rRepositoryErr != nil {
+		return t.rPluginGitHubRepository, t.rRepositoryErr
+	}
+	return &pluginGitHubRepository{Owner: owner, RepoName: repoName, Visibility: "private"}, nil
+}
+
+type testIssueWriter struct {
+	mu      sync.Mutex
+	entries []*auditEntry
+	rErr    error
+}
+
+func (t *testIssueWriter) WriteBack(ctx context.Context, info *pluginGitHubIssue, entry *auditEntry) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries = append(t.entries, entry)
+	return t.rErr
+}
+
 func TestValidate(t *testing.T) {
 	t.Parallel()
 
 	cases := []struct {
 		name      string
-		validator *testIssueMatcher
+		validator *testReferenceMatcher
 		req       *jvspb.ValidateJustificationRequest
 		wantResq  *jvspb.ValidateJustificationResponse
 		wantErr   string
 	}{
 		{
 			name: "success",
-			validator: &testIssueMatcher{
+			validator: &testReferenceMatcher{
 				rPluginGitHubIssue: &pluginGitHubIssue{
-					Owner:       "test-owner",
-					RepoName:    "test-repo-name",
-					IssueNumber: 1,
+					Owner:        "test-owner",
+					RepoName:     "test-repo-name",
+					IssueNumber:  1,
+					SnapshotHash: testOpenIssueSnapshotHash,
 				},
 				rErr: nil,
 			},
@@ -73,12 +134,240 @@ func TestValidate(t *testing.T) {
 					respAnnotationKeyIssueOwner:  "test-owner",
 					respAnnotationKeyIssueRepo:   "test-repo-name",
 					respAnnotationKeyIssueNumber: "1",
+
+					respAnnotationKeyIssueSnapshotHash: testOpenIssueSnapshotHash,
+
+					respAnnotationKeyRepository:           "test-owner/test-repo",
+					respAnnotationKeyRepositoryVisibility: "private",
+					respAnnotationKeyRepositoryArchived:   "false",
+					respAnnotationKeyRepositoryFork:       "false",
+				},
+			},
+		},
+		{
+			name: "discussion_success",
+			validator: &testReferenceMatcher{
+				rPluginGitHubDiscussion: &pluginGitHubDiscussion{
+					Owner:            "test-owner",
+					RepoName:         "test-repo-name",
+					DiscussionNumber: 3,
+					Category:         "Change Reviews",
+					Answered:         true,
+				},
+			},
+			req: &jvspb.ValidateJustificationRequest{
+				Justification: &jvspb.Justification{
+					Category: githubCategory,
+					Value:    testGitHubDiscussionURL,
+				},
+			},
+			wantResq: &jvspb.ValidateJustificationResponse{
+				Valid: true,
+				Annotation: map[string]string{
+					respAnnotationKeyDiscussionURL:      testGitHubDiscussionURL,
+					respAnnotationKeyDiscussionOwner:    "test-owner",
+					respAnnotationKeyDiscussionRepo:     "test-repo-name",
+					respAnnotationKeyDiscussionNumber:   "3",
+					respAnnotationKeyDiscussionCategory: "Change Reviews",
+					respAnnotationKeyDiscussionAnswered: "true",
+
+					respAnnotationKeyRepository:           "test-owner/test-repo",
+					respAnnotationKeyRepositoryVisibility: "private",
+					respAnnotationKeyRepositoryArchived:   "false",
+					respAnnotationKeyRepositoryFork:       "false",
+				},
+			},
+		},
+		{
+			name: "workflow_run_success",
+			validator: &testReferenceMatcher{
+				rPluginGitHubWorkflowRun: &pluginGitHubWorkflowRun{
+					Owner:        "test-owner",
+					RepoName:     "test-repo-name",
+					RunID:        123,
+					WorkflowName: "Deploy",
+					HeadBranch:   "main",
+					HeadSHA:      "abc123",
+					Actor:        "octocat",
+					Status:       "in_progress",
+				},
+			},
+			req: &jvspb.ValidateJustificationRequest{
+				Justification: &jvspb.Justification{
+					Category: githubCategory,
+					Value:    "https://github.com/test-owner/test-repo-name/actions/runs/123",
+				},
+			},
+			wantResq: &jvspb.ValidateJustificationResponse{
+				Valid: true,
+				Annotation: map[string]string{
+					respAnnotationKeyWorkflowRunURL:     "https://github.com/test-owner/test-repo-name/actions/runs/123",
+					respAnnotationKeyWorkflowRunOwner:   "test-owner",
+					respAnnotationKeyWorkflowRunRepo:    "test-repo-name",
+					respAnnotationKeyWorkflowRunID:      "123",
+					respAnnotationKeyWorkflowName:       "Deploy",
+					respAnnotationKeyWorkflowRunHeadSHA: "abc123",
+					respAnnotationKeyWorkflowRunActor:   "octocat",
+
+					respAnnotationKeyRepository:           "test-owner/test-repo-name",
+					respAnnotationKeyRepositoryVisibility: "private",
+					respAnnotationKeyRepositoryArchived:   "false",
+					respAnnotationKeyRepositoryFork:       "false",
+				},
+			},
+		},
+		{
+			name: "deployment_success",
+			validator: &testReferenceMatcher{
+				rPluginGitHubDeployment: &pluginGitHubDeployment{
+					Owner:       "test-owner",
+					RepoName:    "test-repo-name",
+					RunID:       123,
+					Environment: "production",
+					State:       "waiting",
+				},
+			},
+			req: &jvspb.ValidateJustificationRequest{
+				Justification: &jvspb.Justification{
+					Category: githubCategory,
+					Value:    "https://github.com/test-owner/test-repo-name/actions/runs/123?environment=production",
+				},
+			},
+			wantResq: &jvspb.ValidateJustificationResponse{
+				Valid: true,
+				Annotation: map[string]string{
+					respAnnotationKeyDeploymentURL:         "https://github.com/test-owner/test-repo-name/actions/runs/123?environment=production",
+					respAnnotationKeyDeploymentOwner:       "test-owner",
+					respAnnotationKeyDeploymentRepo:        "test-repo-name",
+					respAnnotationKeyDeploymentEnvironment: "production",
+					respAnnotationKeyDeploymentState:       "waiting",
+					respAnnotationKeyWorkflowRunID:         "123",
+
+					respAnnotationKeyRepository:           "test-owner/test-repo-name",
+					respAnnotationKeyRepositoryVisibility: "private",
+					respAnnotationKeyRepositoryArchived:   "false",
+					respAnnotationKeyRepositoryFork:       "false",
+				},
+			},
+		},
+		{
+			name: "security_success",
+			validator: &testReferenceMatcher{
+				rPluginGitHubSecurity: &pluginGitHubSecurityReference{
+					Owner:    "test-owner",
+					RepoName: "test-repo-name",
+					Kind:     "dependabot",
+					ID:       "4",
+					State:    "open",
+					Severity: "high",
+				},
+			},
+			req: &jvspb.ValidateJustificationRequest{
+				Justification: &jvspb.Justification{
+					Category: githubCategory,
+					Value:    "https://github.com/test-owner/test-repo-name/security/dependabot/4",
+				},
+			},
+			wantResq: &jvspb.ValidateJustificationResponse{
+				Valid: true,
+				Annotation: map[string]string{
+					respAnnotationKeySecurityURL:      "https://github.com/test-owner/test-repo-name/security/dependabot/4",
+					respAnnotationKeySecurityOwner:    "test-owner",
+					respAnnotationKeySecurityRepo:     "test-repo-name",
+					respAnnotationKeySecurityKind:     "dependabot",
+					respAnnotationKeySecurityID:       "4",
+					respAnnotationKeySecurityState:    "open",
+					respAnnotationKeySecuritySeverity: "high",
+
+					respAnnotationKeyRepository:           "test-owner/test-repo-name",
+					respAnnotationKeyRepositoryVisibility: "private",
+					respAnnotationKeyRepositoryArchived:   "false",
+					respAnnotationKeyRepositoryFork:       "false",
+				},
+			},
+		},
+		{
+			name: "commit_success",
+			validator: &testReferenceMatcher{
+				rPluginGitHubPullRequest: &pluginGitHubPullRequest{
+					Owner:      "test-owner",
+					RepoName:   "test-repo-name",
+					PullNumber: 7,
+					CommitSHA:  "abc123",
+					State:      "merged",
+					LinkedIssues: []string{
+						"https://github.com/test-owner/test-repo-name/issues/1",
+						"https://github.com/test-owner/test-repo-name/issues/2",
+					},
+				},
+			},
+			req: &jvspb.ValidateJustificationRequest{
+				Justification: &jvspb.Justification{
+					Category: githubCategory,
+					Value:    "https://github.com/test-owner/test-repo-name/commit/abc123",
+				},
+			},
+			wantResq: &jvspb.ValidateJustificationResponse{
+				Valid: true,
+				Annotation: map[string]string{
+					respAnnotationKeyPullRequestURL:    "https://github.com/test-owner/test-repo-name/pull/7",
+					respAnnotationKeyPullRequestOwner:  "test-owner",
+					respAnnotationKeyPullRequestRepo:   "test-repo-name",
+					respAnnotationKeyPullRequestNumber: "7",
+					respAnnotationKeyPullRequestState:  "merged",
+					respAnnotationKeyCommitSHA:         "abc123",
+					respAnnotationKeyLinkedIssues:      "https://github.com/test-owner/test-repo-name/issues/1,https://github.com/test-owner/test-repo-name/issues/2",
+
+					respAnnotationKeyRepository:           "test-owner/test-repo-name",
+					respAnnotationKeyRepositoryVisibility: "private",
+					respAnnotationKeyRepositoryArchived:   "false",
+					respAnnotationKeyRepositoryFork:       "false",
+				},
+			},
+		},
+		{
+			name: "project_item_success",
+			validator: &testReferenceMatcher{
+				rPluginGitHubIssue: &pluginGitHubIssue{
+					Owner:         "test-owner",
+					RepoName:      "test-repo-name",
+					IssueNumber:   1,
+					SnapshotHash:  testOpenIssueSnapshotHash,
+					Project:       "test-owner/5",
+					ProjectStatus: "Approved",
+					Approver:      "alice",
+					ApprovedAt:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+				},
+			},
+			req: &jvspb.ValidateJustificationRequest{
+				Justification: &jvspb.Justification{
+					Category: githubCategory,
+					Value:    "https://github.com/orgs/test-owner/projects/5?pane=issue&issue=test-owner%7Ctest-repo-name%7C1",
+				},
+			},
+			wantResq: &jvspb.ValidateJustificationResponse{
+				Valid: true,
+				Annotation: map[string]string{
+					respAnnotationKeyIssueURL:          "https://github.com/test-owner/test-repo-name/issues/1",
+					respAnnotationKeyIssueOwner:        "test-owner",
+					respAnnotationKeyIssueRepo:         "test-repo-name",
+					respAnnotationKeyIssueNumber:       "1",
+					respAnnotationKeyIssueSnapshotHash: testOpenIssueSnapshotHash,
+					respAnnotationKeyProject:           "test-owner/5",
+					respAnnotationKeyProjectStatus:     "Approved",
+					respAnnotationKeyIssueApprover:     "alice",
+					respAnnotationKeyIssueApprovedAt:   "2026-01-02T03:04:05Z",
+
+					respAnnotationKeyRepository:           "test-owner/test-repo-name",
+					respAnnotationKeyRepositoryVisibility: "private",
+					respAnnotationKeyRepositoryArchived:   "false",
+					respAnnotationKeyRepositoryFork:       "false",
 				},
 			},
 		},
 		{
 			name: "internal_error",
-			validator: &testIssueMatcher{
+			validator: &testReferenceMatcher{
 				rPluginGitHubIssue: nil,
 				rErr:               fmt.Errorf("injected error"),
 			},
@@ -90,9 +379,42 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: "injected error",
 		},
+		{
+			name: "additional_category_success",
+			validator: &testReferenceMatcher{
+				rPluginGitHubIssue: &pluginGitHubIssue{
+					Owner:        "test-owner",
+					RepoName:     "test-repo",
+					IssueNumber:  1,
+					SnapshotHash: testOpenIssueSnapshotHash,
+				},
+			},
+			req: &jvspb.ValidateJustificationRequest{
+				Justification: &jvspb.Justification{
+					Category: "github-incident",
+					Value:    testGitHubIssueURL,
+				},
+			},
+			wantResq: &jvspb.ValidateJustificationResponse{
+				Valid: true,
+				Annotation: map[string]string{
+					respAnnotationKeyIssueURL:    testGitHubIssueURL,
+					respAnnotationKeyIssueOwner:  "test-owner",
+					respAnnotationKeyIssueRepo:   "test-repo",
+					respAnnotationKeyIssueNumber: "1",
+
+					respAnnotationKeyIssueSnapshotHash: testOpenIssueSnapshotHash,
+
+					respAnnotationKeyRepository:           "test-owner/test-repo",
+					respAnnotationKeyRepositoryVisibility: "private",
+					respAnnotationKeyRepositoryArchived:   "false",
+					respAnnotationKeyRepositoryFork:       "false",
+				},
+			},
+		},
 		{
 			name: "wrong_category",
-			validator: &testIssueMatcher{
+			validator: &testReferenceMatcher{
 				rPluginGitHubIssue: nil,
 				rErr:               nil,
 			},
@@ -103,15 +425,38 @@ func TestValidate(t *testing.T) {
 				},
 			},
 			wantResq: &jvspb.ValidateJustificationResponse{
-				Valid: false,
-				Error: []string{`failed to perform validation, expected category "test-category" to be "github"`},
+				Valid:      false,
+				Error:      []string{`[CATEGORY_UNKNOWN] failed to perform validation, expected category "test-category" to be one of ["github" "github-incident"]`},
+				Annotation: map[string]string{respAnnotationKeyReason: string(ReasonCategoryUnknown)},
+			},
+		},
+		{
+			name: "repository_not_allowed",
+			validator: &testReferenceMatcher{
+				rPluginGitHubIssue: &pluginGitHubIssue{
+					Owner:       "test-owner",
+					RepoName:    "test-repo",
+					IssueNumber: 1,
+				},
+				rRepositoryErr: invalidf(ReasonRepoNotAllowed, "repository test-owner/test-repo is archived"),
+			},
+			req: &jvspb.ValidateJustificationRequest{
+				Justification: &jvspb.Justification{
+					Category: githubCategory,
+					Value:    testGitHubIssueURL,
+				},
+			},
+			wantResq: &jvspb.ValidateJustificationResponse{
+				Valid:      false,
+				Error:      []string{"[REPO_NOT_ALLOWED] invalid justification: repository test-owner/test-repo is archived"},
+				Annotation: map[string]string{respAnnotationKeyReason: string(ReasonRepoNotAllowed)},
 			},
 		},
 		{
 			name: "issue_not_found",
-			validator: &testIssueMatcher{
+			validator: &testReferenceMatcher{
 				rPluginGitHubIssue: nil,
-				rErr:               errors.Join(errInvalidJustification, fmt.Errorf("issue not found")),
+				rErr:               invalidf(ReasonReferenceNotFound, "issue not found"),
 			},
 			req: &jvspb.ValidateJustificationRequest{
 				Justification: &jvspb.Justification{
@@ -120,8 +465,9 @@ func TestValidate(t *testing.T) {
 				},
 			},
 			wantResq: &jvspb.ValidateJustificationResponse{
-				Valid: false,
-				Error: []string{"invalid justification\nissue not found"},
+				Valid:      false,
+				Error:      []string{"[REFERENCE_NOT_FOUND] invalid justification: issue not found"},
+				Annotation: map[string]string{respAnnotationKeyReason: string(ReasonReferenceNotFound)},
 			},
 		},
 	}
@@ -133,7 +479,10 @@ func TestValidate(t *testing.T) {
 			t.Parallel()
 
 			p := &GitHubPlugin{
-				validator: tc.validator,
+				categories: map[string]*pluginCategory{
+					githubCategory:    {validator: tc.validator, repositoryPolicy: true},
+					"github-incident": {validator: tc.validator, repositoryPolicy: true},
+				},
 			}
 			gotResq, gotErr := p.Validate(ctx, tc.req)
 			if diff := testutil.DiffErrString(gotErr, tc.wantErr); diff != "" {
@@ -146,22 +495,245 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestValidate_RepositoryLookup(t *testing.T) {
+	t.Parallel()
+
+	issue := &pluginGitHubIssue{Owner: "test-owner", RepoName: "test-repo", IssueNumber: 1}
+	lookupErr := fmt.Errorf("failed to get repository info: injected error")
+
+	cases := []struct {
+		name             string
+		validator        *testReferenceMatcher
+		repositoryPolicy bool
+		wantResq         *jvspb.ValidateJustificationResponse
+		wantErr          string
+	}{
+		{
+			name: "malformed_reference_not_looked_up",
+			validator: &testReferenceMatcher{
+				rErr:           invalidf(ReasonReferenceMalformed, "invalid issue url"),
+				rRepositoryErr: lookupErr,
+			},
+			repositoryPolicy: true,
+			wantResq: &jvspb.ValidateJustificationResponse{
+				Valid:      false,
+				Error:      []string{"[REFERENCE_MALFORMED] invalid justification: invalid issue url"},
+				Annotation: map[string]string{respAnnotationKeyReason: string(ReasonReferenceMalformed)},
+			},
+		},
+		{
+			name: "no_repository_policy",
+			validator: &testReferenceMatcher{
+				rPluginGitHubIssue: issue,
+				rRepositoryErr:     lookupErr,
+			},
+			wantResq: &jvspb.ValidateJustificationResponse{
+				Valid: true,
+				Annotation: map[string]string{
+					respAnnotationKeyIssueURL:          testGitHubIssueURL,
+					respAnnotationKeyIssueOwner:        "test-owner",
+					respAnnotationKeyIssueRepo:         "test-repo",
+					respAnnotationKeyIssueNumber:       "1",
+					respAnnotationKeyIssueSnapshotHash: "",
+				},
+			},
+		},
+		{
+			name: "repository_lookup_error",
+			validator: &testReferenceMatcher{
+				rPluginGitHubIssue: issue,
+				rRepositoryErr:     lookupErr,
+			},
+			repositoryPolicy: true,
+			wantErr:          "failed to get repository info",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &GitHubPlugin{
+				categories: map[string]*pluginCategory{
+					githubCategory: {validator: tc.validator, repositoryPolicy: tc.repositoryPolicy},
+				},
+			}
+			gotResq, gotErr := p.Validate(t.Context(), &jvspb.ValidateJustificationRequest{
+				Justification: &jvspb.Justification{
+					Category: githubCategory,
+					Value:    testGitHubIssueURL,
+				},
+			})
+			if diff := testutil.DiffErrString(gotErr, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tc.wantResq, gotResq, cmpopts.IgnoreUnexported(jvspb.ValidateJustificationResponse{})); diff != "" {
+				t.Errorf("Failed validation (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_WriteBack(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cases := []struct {
+		name        string
+		matchErr    error
+		writeErr    error
+		wantValid   bool
+		wantEntries []*auditEntry
+	}{
+		{
+			name:      "success",
+			wantValid: true,
+			wantEntries: []*auditEntry{
+				{Time: now, Requester: "user@example.com", Audience: "prod"},
+			},
+		},
+		{
+			name:      "write_back_error_does_not_fail_validation",
+			writeErr:  fmt.Errorf("injected error"),
+			wantValid: true,
+			wantEntries: []*auditEntry{
+				{Time: now, Requester: "user@example.com", Audience: "prod"},
+			},
+		},
+		{
+			name:     "no_write_back_when_invalid",
+			matchErr: errors.Join(errInvalidJustification, fmt.Errorf("issue not found")),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			writer := &testIssueWriter{rErr: tc.writeErr}
+			p := &GitHubPlugin{
+				categories: map[string]*pluginCategory{
+					githubCategory: {
+						validator: &testReferenceMatcher{
+							rPluginGitHubIssue: &pluginGitHubIssue{Owner: "test-owner", RepoName: "test-repo-name", IssueNumber: 1},
+							rErr:               tc.matchErr,
+						},
+					},
+				},
+				writer: writer,
+				now:    func() time.Time { return now },
+			}
+			gotResq, err := p.Validate(t.Context(), &jvspb.ValidateJustificationRequest{
+				Justification: &jvspb.Justification{
+					Category: githubCategory,
+					Value:    testGitHubIssueURL,
+					Annotation: map[string]string{
+						reqAnnotationKeyRequester: "user@example.com",
+						reqAnnotationKeyAudience:  "prod",
+					},
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := p.Close(t.Context()); err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := gotResq.GetValid(), tc.wantValid; got != want {
+				t.Errorf("Validate() valid = %t, want %t", got, want)
+			}
+			if diff := cmp.Diff(tc.wantEntries, writer.entries); diff != "" {
+				t.Errorf("unexpected write-back entries (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// testBlockingIssueWriter is an issueWriter whose write-backs block until
+// release is closed.
+type testBlockingIssueWriter struct {
+	release chan struct{}
+}
+
+func (t *testBlockingIssueWriter) WriteBack(ctx context.Context, info *pluginGitHubIssue, entry *auditEntry) error {
+	<-t.release
+	return nil
+}
+
+func TestGitHubPlugin_Close(t *testing.T) {
+	t.Parallel()
+
+	writer := &testBlockingIssueWriter{release: make(chan struct{})}
+	p := &GitHubPlugin{writer: writer}
+	p.writeBackAsync(t.Context(), &pluginGitHubIssue{}, &auditEntry{})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	if diff := testutil.DiffErrString(p.Close(ctx), "failed to wait for write-backs: context deadline exceeded"); diff != "" {
+		t.Error(diff)
+	}
+
+	close(writer.release)
+	if err := p.Close(t.Context()); err != nil {
+		t.Errorf("Close() unexpected error: %v", err)
+	}
+}
+
 func TestGetUIData(t *testing.T) {
 	t.Parallel()
-	ctx := t.Context()
 
 	uiData := &jvspb.UIData{
 		DisplayName: testGitHubPluginDisplayName,
 		Hint:        testGitHubPluginHint,
 	}
-	p := &GitHubPlugin{
-		uiData: uiData,
+	incidentUIData := &jvspb.UIData{
+		DisplayName: "GitHub incident",
+		Hint:        "Incident issue URL",
 	}
-	gotData, err := p.GetUIData(ctx, &jvspb.GetUIDataRequest{})
-	if err != nil {
-		t.Fatalf("failed to get UI data: %v", err)
+
+	cases := []struct {
+		name     string
+		category string
+		want     *jvspb.UIData
+		wantErr  string
+	}{
+		{
+			name:     "default_category",
+			category: githubCategory,
+			want:     uiData,
+		},
+		{
+			name:     "additional_category",
+			category: "github-incident",
+			want:     incidentUIData,
+		},
+		{
+			name:     "unknown_category",
+			category: "github-pr",
+			wantErr:  `category "github-pr" is not configured`,
+		},
 	}
-	if diff := cmp.Diff(uiData, gotData, cmpopts.IgnoreUnexported(jvspb.UIData{})); diff != "" {
-		t.Errorf("Failed validation (-want,+got):\n%s", diff)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &GitHubPlugin{
+				categories: map[string]*pluginCategory{
+					githubCategory:    {uiData: uiData},
+					"github-incident": {uiData: incidentUIData},
+				},
+				category: tc.category,
+			}
+			gotData, err := p.GetUIData(t.Context(), &jvspb.GetUIDataRequest{})
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tc.want, gotData, cmpopts.IgnoreUnexported(jvspb.UIData{})); diff != "" {
+				t.Errorf("GetUIData() unexpected diff (-want,+got):\n%s", diff)
+			}
+		})
 	}
 }
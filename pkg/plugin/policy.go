@@ -0,0 +1,683 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/abcxyz/pkg/cli"
+)
+
+const (
+	// Allowed values of Policy.DiscussionAnswered.
+	discussionAnsweredAny        = "any"
+	discussionAnsweredAnswered   = "answered"
+	discussionAnsweredUnanswered = "unanswered"
+
+	// Allowed values of Policy.ReferenceMode.
+	referenceModeAll = "all"
+	referenceModeAny = "any"
+
+	// defaultMaxReferences is the default of Policy.MaxReferences.
+	defaultMaxReferences = 5
+)
+
+// Policy defines the criteria a justification must satisfy beyond the
+// referenced GitHub object existing and being open. The zero value accepts
+// every open object.
+type Policy struct {
+	// ReferenceMode is whether all or any of the references of a
+	// justification with several references must be valid, "all" or "any".
+	// Defaults to "all".
+	ReferenceMode string
+
+	// MaxReferences is the maximum number of references in a justification.
+	MaxReferences int
+
+	// Enforcement are the enforcement levels of the policy rules with a
+	// reason, in the "REASON=level" format, or of a single rule named after
+	// its option, in the "RULE=level" format, where the level is "enforce",
+	// "warn" or "off". The level of a rule takes precedence over the level of
+	// its reason. Violations of rules in the "warn" level are reported as
+	// warnings without failing the validation. Rules are enforced by default.
+	Enforcement []string
+
+	// RepositoryVisibilities restricts the repositories of references to these
+	// visibilities, "public", "private" or "internal". Any visibility is
+	// allowed when empty.
+	RepositoryVisibilities []string
+
+	// RepositoryDenyArchived rejects references in archived repositories.
+	RepositoryDenyArchived bool
+
+	// RepositoryDenyForks rejects references in forked repositories.
+	RepositoryDenyForks bool
+
+	// RepositoryTopics are the topics the repositories of references must all
+	// have, e.g. "production-service".
+	RepositoryTopics []string
+
+	// RepositoryProperties are custom property values the repositories of
+	// references must all have, in the "name=value" format.
+	RepositoryProperties []string
+
+	// RepositoryPropertyLabels require issues in repositories with a custom
+	// property value to have a label, in the "name=value:label" format.
+	RepositoryPropertyLabels []string
+
+	// RepositoryCacheTTL is how long repository metadata is cached. Defaults
+	// to 5 minutes.
+	RepositoryCacheTTL time.Duration
+
+	// DiscussionCategories restricts discussions to these category names. Any
+	// category is allowed when empty.
+	DiscussionCategories []string
+
+	// DiscussionAnswered is one of "any", "answered" or "unanswered".
+	DiscussionAnswered string
+
+	// DiscussionAllowLocked allows locked discussions.
+	DiscussionAllowLocked bool
+
+	// DiscussionMaxAge rejects discussions created longer ago than this. There
+	// is no limit when zero.
+	DiscussionMaxAge time.Duration
+
+	// Project requires issues to be items of this project (v2), in the
+	// "owner/number" format. Project item URLs are accepted as justifications
+	// either way.
+	Project string
+
+	// ProjectStatusField is the single-select field holding the status of
+	// project items. Defaults to "Status".
+	ProjectStatusField string
+
+	// ProjectAllowedStatuses restricts the status of project items. Any status
+	// is allowed when empty.
+	ProjectAllowedStatuses []string
+
+	// WorkflowRunCompletedWindow accepts workflow runs completed within this
+	// duration. Only queued and in progress runs are accepted when zero.
+	WorkflowRunCompletedWindow time.Duration
+
+	// WorkflowPaths restricts workflow runs to these workflow files, e.g.
+	// ".github/workflows/deploy.yml". Any workflow is allowed when empty.
+	WorkflowPaths []string
+
+	// WorkflowBranches restricts workflow runs to these head branches. Any
+	// branch is allowed when empty.
+	WorkflowBranches []string
+
+	// DeploymentEnvironments restricts deployments to these environments. Any
+	// environment is allowed when empty.
+	DeploymentEnvironments []string
+
+	// DeploymentAllowedStates restricts the state of deployments. Deployments
+	// waiting for approval, pending, queued or in progress are allowed when
+	// empty.
+	DeploymentAllowedStates []string
+
+	// PullRequestMergedWindow accepts pull requests merged within this
+	// duration. Only open pull requests are accepted when zero.
+	PullRequestMergedWindow time.Duration
+
+	// PullRequestBaseBranches restricts pull requests to these base branches.
+	// Any branch is allowed when empty.
+	PullRequestBaseBranches []string
+
+	// IssueRequireLinkedPullRequest requires issues to have at least one
+	// open or merged pull request linked in their Development section, in a
+	// repository readable with the token of the issue repository.
+	IssueRequireLinkedPullRequest bool
+
+	// PullRequestRequireLinkedIssue requires pull requests to close at least
+	// one issue readable with the token of the pull request repository.
+	PullRequestRequireLinkedIssue bool
+
+	// IssueMilestones restricts issues to these milestone titles. Any or no
+	// milestone is allowed when empty.
+	IssueMilestones []string
+
+	// IssueRequireOpenMilestone requires issues to be in an open milestone
+	// which is not past due.
+	IssueRequireOpenMilestone bool
+
+	// IssueTypes restricts issues to these issue types, e.g. "Incident". Any
+	// or no type is allowed when empty.
+	IssueTypes []string
+
+	// IssueRequiredSections are the markdown sections the issue body must have
+	// with non-empty content, matched by heading case insensitively.
+	IssueRequiredSections []string
+
+	// IssueBodyPatterns are regular expressions the issue body must match.
+	IssueBodyPatterns []string
+
+	// IssueAuthorTeams requires the issue author to be a member of one of these
+	// teams, in the "org/team-slug" format.
+	IssueAuthorTeams []string
+
+	// IssueAssigneeTeams requires at least one issue assignee to be a member
+	// of one of these teams, in the "org/team-slug" format.
+	IssueAssigneeTeams []string
+
+	// TeamCacheTTL is how long team memberships are cached. Defaults to 5
+	// minutes.
+	TeamCacheTTL time.Duration
+
+	// ApprovalTeams requires issues to be approved by a member of one of these
+	// teams, in the "org/team-slug" format, other than the issue author. The
+	// requester is not known to plugins, so approvals by the requester are not
+	// excluded. No approval is required when empty.
+	ApprovalTeams []string
+
+	// ApprovalReaction is the reaction approving an issue. Defaults to "+1".
+	ApprovalReaction string
+
+	// ApprovalComment is the comment line approving an issue. Defaults to
+	// "/approve".
+	ApprovalComment string
+
+	// DegradedMode accepts references while GitHub is unreachable, failing
+	// with transport errors or 5xx responses, when they were validated
+	// successfully within DegradedWindow or are in DegradedAllowlist.
+	DegradedMode bool
+
+	// DegradedWindow is how long successfully validated references are
+	// accepted in degraded mode. Defaults to 1 hour.
+	DegradedWindow time.Duration
+
+	// DegradedAllowlist are the reference URLs accepted in degraded mode
+	// without a recent validation.
+	DegradedAllowlist []string
+}
+
+// Validate validates if the policy is valid and sets defaults.
+func (p *Policy) Validate() error {
+	var rErr error
+
+	switch p.ReferenceMode {
+	case "":
+		p.ReferenceMode = referenceModeAll
+	case referenceModeAll, referenceModeAny:
+	default:
+		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_REFERENCE_MODE must be one of %q or %q, got %q",
+			referenceModeAll, referenceModeAny, p.ReferenceMode))
+	}
+	if p.MaxReferences == 0 {
+		p.MaxReferences = defaultMaxReferences
+	} else if p.MaxReferences < 0 {
+		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_MAX_REFERENCES must be positive, got %d", p.MaxReferences))
+	}
+
+	for _, e := range p.Enforcement {
+		if _, _, err := parseEnforcement(e); err != nil {
+			rErr = errors.Join(rErr, fmt.Errorf("invalid GITHUB_POLICY_ENFORCEMENT: %w", err))
+		}
+	}
+
+	for _, v := range p.RepositoryVisibilities {
+		if !slices.Contains(repositoryVisibilities, v) {
+			rErr = errors.Join(rErr, fmt.Errorf("GITHUB_REPOSITORY_VISIBILITIES must be in %q, got %q", repositoryVisibilities, v))
+		}
+	}
+	for _, property := range p.RepositoryProperties {
+		if _, _, err := parsePropertyCondition(property); err != nil {
+			rErr = errors.Join(rErr, fmt.Errorf("invalid GITHUB_REPOSITORY_PROPERTIES: %w", err))
+		}
+	}
+	for _, rule := range p.RepositoryPropertyLabels {
+		if _, err := parsePropertyLabelRule(rule); err != nil {
+			rErr = errors.Join(rErr, fmt.Errorf("invalid GITHUB_REPOSITORY_PROPERTY_LABELS: %w", err))
+		}
+	}
+	if p.RepositoryCacheTTL == 0 {
+		p.RepositoryCacheTTL = defaultRepositoryCacheTTL
+	} else if p.RepositoryCacheTTL < 0 {
+		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_REPOSITORY_CACHE_TTL must be positive, got %s", p.RepositoryCacheTTL))
+	}
+
+	switch p.DiscussionAnswered {
+	case "":
+		p.DiscussionAnswered = discussionAnsweredAny
+	case discussionAnsweredAny, discussionAnsweredAnswered, discussionAnsweredUnanswered:
+	default:
+		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_DISCUSSION_ANSWERED must be one of %q, %q or %q, got %q",
+			discussionAnsweredAny, discussionAnsweredAnswered, discussionAnsweredUnanswered, p.DiscussionAnswered))
+	}
+	if p.DiscussionMaxAge < 0 {
+		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_DISCUSSION_MAX_AGE must be positive, got %s", p.DiscussionMaxAge))
+	}
+	if p.Project != "" {
+		if _, err := parseProjectRef(p.Project); err != nil {
+			rErr = errors.Join(rErr, fmt.Errorf("invalid GITHUB_PROJECT: %w", err))
+		}
+	}
+	if p.ProjectStatusField == "" {
+		p.ProjectStatusField = defaultProjectStatusField
+	}
+	if p.WorkflowRunCompletedWindow < 0 {
+		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_WORKFLOW_RUN_COMPLETED_WINDOW must be positive, got %s", p.WorkflowRunCompletedWindow))
+	}
+	if p.PullRequestMergedWindow < 0 {
+		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_PULL_REQUEST_MERGED_WINDOW must be positive, got %s", p.PullRequestMergedWindow))
+	}
+	for _, pattern := range p.IssueBodyPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			rErr = errors.Join(rErr, fmt.Errorf("GITHUB_ISSUE_BODY_PATTERNS pattern %q is invalid: %w", pattern, err))
+		}
+	}
+	rErr = errors.Join(rErr,
+		validateTeams("GITHUB_ISSUE_AUTHOR_TEAMS", p.IssueAuthorTeams),
+		validateTeams("GITHUB_ISSUE_ASSIGNEE_TEAMS", p.IssueAssigneeTeams),
+		validateTeams("GITHUB_APPROVAL_TEAMS", p.ApprovalTeams))
+	if p.TeamCacheTTL == 0 {
+		p.TeamCacheTTL = defaultTeamCacheTTL
+	} else if p.TeamCacheTTL < 0 {
+		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_TEAM_CACHE_TTL must be positive, got %s", p.TeamCacheTTL))
+	}
+	if p.ApprovalReaction == "" {
+		p.ApprovalReaction = defaultApprovalReaction
+	} else if !slices.Contains(approvalReactions, p.ApprovalReaction) {
+		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_APPROVAL_REACTION must be one of %q, got %q", approvalReactions, p.ApprovalReaction))
+	}
+	if p.ApprovalComment == "" {
+		p.ApprovalComment = defaultApprovalComment
+	}
+	for _, s := range p.DeploymentAllowedStates {
+		if !slices.Contains(deploymentStates, s) {
+			rErr = errors.Join(rErr, fmt.Errorf("GITHUB_DEPLOYMENT_ALLOWED_STATES must be in %q, got %q", deploymentStates, s))
+		}
+	}
+
+	if p.DegradedWindow == 0 {
+		p.DegradedWindow = defaultDegradedWindow
+	} else if p.DegradedWindow < 0 {
+		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_DEGRADED_WINDOW must be positive, got %s", p.DegradedWindow))
+	}
+	if len(p.DegradedAllowlist) > 0 && !p.DegradedMode {
+		rErr = errors.Join(rErr, fmt.Errorf("GITHUB_DEGRADED_ALLOWLIST requires GITHUB_DEGRADED_MODE"))
+	}
+	rErr = errors.Join(rErr, validateDegradedAllowlist(p.DegradedAllowlist))
+
+	return rErr
+}
+
+// validateTeams validates the teams configured in envVar are in the
+// "org/team-slug" format.
+func validateTeams(envVar string, teams []string) error {
+	var rErr error
+	for _, team := range teams {
+		if org, slug, ok := strings.Cut(team, "/"); !ok || org == "" || slug == "" {
+			rErr = errors.Join(rErr, fmt.Errorf("%s team %q must be in the format org/team-slug", envVar, team))
+		}
+	}
+	return rErr
+}
+
+// ToFlags binds the policy to the given [cli.FlagSet] and returns it.
+func (p *Policy) ToFlags(set *cli.FlagSet) *cli.FlagSet {
+	f := set.NewSection("REFERENCE POLICY OPTIONS")
+
+	f.StringVar(&cli.StringVar{
+		Name:    "github-reference-mode",
+		Target:  &p.ReferenceMode,
+		EnvVar:  "GITHUB_REFERENCE_MODE",
+		Example: referenceModeAny,
+		Usage: fmt.Sprintf("Whether all or any of the comma or whitespace separated references of a justification must be valid, %q or %q. Defaults to %q.",
+			referenceModeAll, referenceModeAny, referenceModeAll),
+	})
+
+	f.IntVar(&cli.IntVar{
+		Name:    "github-max-references",
+		Target:  &p.MaxReferences,
+		EnvVar:  "GITHUB_MAX_REFERENCES",
+		Example: "2",
+		Usage:   fmt.Sprintf("The maximum number of references in a justification. Defaults to %d.", defaultMaxReferences),
+	})
+
+	f.StringSliceVar(&cli.StringSliceVar{
+		Name:    "github-policy-enforcement",
+		Target:  &p.Enforcement,
+		EnvVar:  "GITHUB_POLICY_ENFORCEMENT",
+		Example: "LABEL_MISSING=warn",
+		Usage: fmt.Sprintf("The enforcement levels of policy rules by reason, in the REASON=level format, or by rule option, "+
+			"e.g. GITHUB_REPOSITORY_DENY_ARCHIVED=warn, where the level is one of %q. The level of a rule takes precedence over its reason. "+
+			"Violations of rules in the %q level don't fail the validation and are reported as warnings. Rules are enforced by default.",
+			enforcementLevels, enforcementWarn),
+	})
+
+	f = set.NewSection("REPOSITORY POLICY OPTIONS")
+
+	f.StringSliceVar(&cli.StringSliceVar{
+		Name:    "github-repository-visibilities",
+		Target:  &p.RepositoryVisibilities,
+		EnvVar:  "GITHUB_REPOSITORY_VISIBILITIES",
+		Example: "private,internal",
+		Usage:   fmt.Sprintf("Visibilities of the repositories references are allowed in, any of %q. Any visibility is allowed if unset.", repositoryVisibilities),
+	})
+
+	f.BoolVar(&cli.BoolVar{
+		Name:   "github-repository-deny-archived",
+		Target: &p.RepositoryDenyArchived,
+		EnvVar: "GITHUB_REPOSITORY_DENY_ARCHIVED",
+		Usage:  "Reject references in archived repositories.",
+	})
+
+	f.BoolVar(&cli.BoolVar{
+		Name:   "github-repository-deny-forks",
+		Target: &p.RepositoryDenyForks,
+		EnvVar: "GITHUB_REPOSITORY_DENY_FORKS",
+		Usage:  "Reject references in forked repositories.",
+	})
+
+	f.StringSliceVar(&cli.StringSliceVar{
+		Name:    "github-repository-topics",
+		Target:  &p.RepositoryTopics,
+		EnvVar:  "GITHUB_REPOSITORY_TOPICS",
+		Example: "production-service",
+		Usage:   "Topics the repositories of references must all have.",
+	})
+
+	f.StringSliceVar(&cli.StringSliceVar{
+		Name:    "github-repository-properties",
+		Target:  &p.RepositoryProperties,
+		EnvVar:  "GITHUB_REPOSITORY_PROPERTIES",
+		Example: "tier=critical",
+		Usage:   "Custom property values the repositories of references must all have, in the format name=value.",
+	})
+
+	f.StringSliceVar(&cli.StringSliceVar{
+		Name:    "github-repository-property-labels",
+		Target:  &p.RepositoryPropertyLabels,
+		EnvVar:  "GITHUB_REPOSITORY_PROPERTY_LABELS",
+		Example: "tier=critical:incident",
+		Usage:   "Labels required on issues in repositories with a custom property value, in the format name=value:label.",
+	})
+
+	f.DurationVar(&cli.DurationVar{
+		Name:    "github-repository-cache-ttl",
+		Target:  &p.RepositoryCacheTTL,
+		EnvVar:  "GITHUB_REPOSITORY_CACHE_TTL",
+		Example: "10m",
+		Usage:   fmt.Sprintf("How long repository metadata is cached. Defaults to %s.", defaultRepositoryCacheTTL),
+	})
+
+	f = set.NewSection("DISCUSSION POLICY OPTIONS")
+
+	f.StringSliceVar(&cli.StringSliceVar{
+		Name:    "github-discussion-categories",
+		Target:  &p.DiscussionCategories,
+		EnvVar:  "GITHUB_DISCUSSION_CATEGORIES",
+		Example: "Change Reviews",
+		Usage:   "Discussion categories allowed as justifications. Any category is allowed if unset.",
+	})
+
+	f.StringVar(&cli.StringVar{
+		Name:    "github-discussion-answered",
+		Target:  &p.DiscussionAnswered,
+		EnvVar:  "GITHUB_DISCUSSION_ANSWERED",
+		Example: discussionAnsweredAnswered,
+		Usage: fmt.Sprintf("Whether discussions must be answered, one of %q, %q or %q.",
+			discussionAnsweredAny, discussionAnsweredAnswered, discussionAnsweredUnanswered),
+	})
+
+	f.BoolVar(&cli.BoolVar{
+		Name:   "github-discussion-allow-locked",
+		Target: &p.DiscussionAllowLocked,
+		EnvVar: "GITHUB_DISCUSSION_ALLOW_LOCKED",
+		Usage:  "Allow locked discussions as justifications.",
+	})
+
+	f.DurationVar(&cli.DurationVar{
+		Name:    "github-discussion-max-age",
+		Target:  &p.DiscussionMaxAge,
+		EnvVar:  "GITHUB_DISCUSSION_MAX_AGE",
+		Example: "720h",
+		Usage:   "Reject discussions created longer ago than this. No limit if unset.",
+	})
+
+	f = set.NewSection("PROJECT POLICY OPTIONS")
+
+	f.StringVar(&cli.StringVar{
+		Name:    "github-project",
+		Target:  &p.Project,
+		EnvVar:  "GITHUB_PROJECT",
+		Example: "my-org/5",
+		Usage:   "Require issues to be items of this project (v2), in the format owner/number.",
+	})
+
+	f.StringVar(&cli.StringVar{
+		Name:    "github-project-status-field",
+		Target:  &p.ProjectStatusField,
+		EnvVar:  "GITHUB_PROJECT_STATUS_FIELD",
+		Example: defaultProjectStatusField,
+		Usage:   fmt.Sprintf("The single-select field holding the status of project items. Defaults to %q.", defaultProjectStatusField),
+	})
+
+	f.StringSliceVar(&cli.StringSliceVar{
+		Name:    "github-project-allowed-statuses",
+		Target:  &p.ProjectAllowedStatuses,
+		EnvVar:  "GITHUB_PROJECT_ALLOWED_STATUSES",
+		Example: "Approved,In Progress",
+		Usage:   "Statuses project items are allowed to be in. Any status is allowed if unset.",
+	})
+
+	f = set.NewSection("WORKFLOW RUN POLICY OPTIONS")
+
+	f.DurationVar(&cli.DurationVar{
+		Name:    "github-workflow-run-completed-window",
+		Target:  &p.WorkflowRunCompletedWindow,
+		EnvVar:  "GITHUB_WORKFLOW_RUN_COMPLETED_WINDOW",
+		Example: "30m",
+		Usage:   "Accept workflow runs completed within this duration. Only queued and in progress runs are accepted if unset.",
+	})
+
+	f.StringSliceVar(&cli.StringSliceVar{
+		Name:    "github-workflow-paths",
+		Target:  &p.WorkflowPaths,
+		EnvVar:  "GITHUB_WORKFLOW_PATHS",
+		Example: ".github/workflows/deploy.yml",
+		Usage:   "Workflow files whose runs are allowed as justifications. Any workflow is allowed if unset.",
+	})
+
+	f.StringSliceVar(&cli.StringSliceVar{
+		Name:    "github-workflow-branches",
+		Target:  &p.WorkflowBranches,
+		EnvVar:  "GITHUB_WORKFLOW_BRANCHES",
+		Example: "main",
+		Usage:   "Head branches of workflow runs allowed as justifications. Any branch is allowed if unset.",
+	})
+
+	f = set.NewSection("DEPLOYMENT POLICY OPTIONS")
+
+	f.StringSliceVar(&cli.StringSliceVar{
+		Name:    "github-deployment-environments",
+		Target:  &p.DeploymentEnvironments,
+		EnvVar:  "GITHUB_DEPLOYMENT_ENVIRONMENTS",
+		Example: "production",
+		Usage:   "Environments whose deployments are allowed as justifications. Any environment is allowed if unset.",
+	})
+
+	f.StringSliceVar(&cli.StringSliceVar{
+		Name:    "github-deployment-allowed-states",
+		Target:  &p.DeploymentAllowedStates,
+		EnvVar:  "GITHUB_DEPLOYMENT_ALLOWED_STATES",
+		Example: "waiting,in_progress",
+		Usage:   fmt.Sprintf("States deployments are allowed to be in. Defaults to %q.", defaultDeploymentAllowedStates),
+	})
+
+	f = set.NewSection("PULL REQUEST POLICY OPTIONS")
+
+	f.DurationVar(&cli.DurationVar{
+		Name:    "github-pull-request-merged-window",
+		Target:  &p.PullRequestMergedWindow,
+		EnvVar:  "GITHUB_PULL_REQUEST_MERGED_WINDOW",
+		Example: "24h",
+		Usage:   "Accept pull requests merged within this duration. Only open pull requests are accepted if unset.",
+	})
+
+	f.StringSliceVar(&cli.StringSliceVar{
+		Name:    "github-pull-request-base-branches",
+		Target:  &p.PullRequestBaseBranches,
+		EnvVar:  "GITHUB_PULL_REQUEST_BASE_BRANCHES",
+		Example: "main",
+		Usage:   "Base branches of pull requests allowed as justifications. Any branch is allowed if unset.",
+	})
+
+	f = set.NewSection("LINKED ITEM POLICY OPTIONS")
+
+	f.BoolVar(&cli.BoolVar{
+		Name:   "github-issue-require-linked-pull-request",
+		Target: &p.IssueRequireLinkedPullRequest,
+		EnvVar: "GITHUB_ISSUE_REQUIRE_LINKED_PULL_REQUEST",
+		Usage:  "Require issues to have at least one open or merged pull request linked in their Development section. Pull requests which only mention the issue don't count.",
+	})
+
+	f.BoolVar(&cli.BoolVar{
+		Name:   "github-pull-request-require-linked-issue",
+		Target: &p.PullRequestRequireLinkedIssue,
+		EnvVar: "GITHUB_PULL_REQUEST_REQUIRE_LINKED_ISSUE",
+		Usage:  "Require pull requests to close at least one issue.",
+	})
+
+	f = set.NewSection("ISSUE POLICY OPTIONS")
+
+	f.StringSliceVar(&cli.StringSliceVar{
+		Name:    "github-issue-milestones",
+		Target:  &p.IssueMilestones,
+		EnvVar:  "GITHUB_ISSUE_MILESTONES",
+		Example: "Q3 Incidents",
+		Usage:   "Milestones issues are allowed to be in. Any or no milestone is allowed if unset.",
+	})
+
+	f.BoolVar(&cli.BoolVar{
+		Name:   "github-issue-require-open-milestone",
+		Target: &p.IssueRequireOpenMilestone,
+		EnvVar: "GITHUB_ISSUE_REQUIRE_OPEN_MILESTONE",
+		Usage:  "Require issues to be in an open milestone which is not past due.",
+	})
+
+	f.StringSliceVar(&cli.StringSliceVar{
+		Name:    "github-issue-types",
+		Target:  &p.IssueTypes,
+		EnvVar:  "GITHUB_ISSUE_TYPES",
+		Example: "Incident,Change",
+		Usage:   "Issue types allowed as justifications. Any or no type is allowed if unset.",
+	})
+
+	f = set.NewSection("ISSUE BODY POLICY OPTIONS")
+
+	f.StringSliceVar(&cli.StringSliceVar{
+		Name:    "github-issue-required-sections",
+		Target:  &p.IssueRequiredSections,
+		EnvVar:  "GITHUB_ISSUE_REQUIRED_SECTIONS",
+		Example: "Impact,Rollback plan",
+		Usage:   "Markdown sections the issue body must have with non-empty content.",
+	})
+
+	f.StringSliceVar(&cli.StringSliceVar{
+		Name:    "github-issue-body-patterns",
+		Target:  &p.IssueBodyPatterns,
+		EnvVar:  "GITHUB_ISSUE_BODY_PATTERNS",
+		Example: `TICKET-[0-9]+`,
+		Usage:   "Regular expressions the issue body must match.",
+	})
+
+	f = set.NewSection("TEAM POLICY OPTIONS")
+
+	f.StringSliceVar(&cli.StringSliceVar{
+		Name:    "github-issue-author-teams",
+		Target:  &p.IssueAuthorTeams,
+		EnvVar:  "GITHUB_ISSUE_AUTHOR_TEAMS",
+		Example: "my-org/oncall",
+		Usage:   "Require the issue author to be a member of one of these teams, in the format org/team-slug.",
+	})
+
+	f.StringSliceVar(&cli.StringSliceVar{
+		Name:    "github-issue-assignee-teams",
+		Target:  &p.IssueAssigneeTeams,
+		EnvVar:  "GITHUB_ISSUE_ASSIGNEE_TEAMS",
+		Example: "my-org/oncall",
+		Usage:   "Require an issue assignee to be a member of one of these teams, in the format org/team-slug.",
+	})
+
+	f.DurationVar(&cli.DurationVar{
+		Name:    "github-team-cache-ttl",
+		Target:  &p.TeamCacheTTL,
+		EnvVar:  "GITHUB_TEAM_CACHE_TTL",
+		Example: "10m",
+		Usage:   fmt.Sprintf("How long team memberships are cached. Defaults to %s.", defaultTeamCacheTTL),
+	})
+
+	f = set.NewSection("APPROVAL POLICY OPTIONS")
+
+	f.StringSliceVar(&cli.StringSliceVar{
+		Name:    "github-approval-teams",
+		Target:  &p.ApprovalTeams,
+		EnvVar:  "GITHUB_APPROVAL_TEAMS",
+		Example: "my-org/sre",
+		Usage:   "Require issues to be approved by a member of one of these teams other than the issue author, in the format org/team-slug. Approvals by the requester are NOT excluded, as JVS doesn't pass the requester to plugins. No approval is required if unset.",
+	})
+
+	f.StringVar(&cli.StringVar{
+		Name:    "github-approval-reaction",
+		Target:  &p.ApprovalReaction,
+		EnvVar:  "GITHUB_APPROVAL_REACTION",
+		Example: "rocket",
+		Usage:   fmt.Sprintf("The reaction approving an issue. Defaults to %q.", defaultApprovalReaction),
+	})
+
+	f.StringVar(&cli.StringVar{
+		Name:    "github-approval-comment",
+		Target:  &p.ApprovalComment,
+		EnvVar:  "GITHUB_APPROVAL_COMMENT",
+		Example: "/lgtm",
+		Usage:   fmt.Sprintf("The comment line approving an issue. Defaults to %q.", defaultApprovalComment),
+	})
+
+	f = set.NewSection("DEGRADED MODE OPTIONS")
+
+	f.BoolVar(&cli.BoolVar{
+		Name:   "github-degraded-mode",
+		Target: &p.DegradedMode,
+		EnvVar: "GITHUB_DEGRADED_MODE",
+		Usage: "Accept references while GitHub is unreachable when they were validated successfully within the degraded window " +
+			"or are in the degraded allowlist, annotating the response with github_validation_degraded.",
+	})
+
+	f.DurationVar(&cli.DurationVar{
+		Name:    "github-degraded-window",
+		Target:  &p.DegradedWindow,
+		EnvVar:  "GITHUB_DEGRADED_WINDOW",
+		Example: "30m",
+		Usage:   fmt.Sprintf("How long successfully validated references are accepted in degraded mode. Defaults to %s.", defaultDegradedWindow),
+	})
+
+	f.StringSliceVar(&cli.StringSliceVar{
+		Name:    "github-degraded-allowlist",
+		Target:  &p.DegradedAllowlist,
+		EnvVar:  "GITHUB_DEGRADED_ALLOWLIST",
+		Example: "https://github.com/owner/repo/issues/1",
+		Usage:   "Reference URLs accepted in degraded mode without a recent validation.",
+	})
+
+	return set
+}
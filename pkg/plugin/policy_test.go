@@ -0,0 +1,280 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/abcxyz/pkg/cli"
+	"github.com/abcxyz/pkg/testutil"
+)
+
+func TestPolicy_ToFlags(t *testing.T) {
+	t.Parallel()
+
+	got := &Policy{}
+	set := cli.NewFlagSet(cli.WithLookupEnv(cli.MapLookuper(map[string]string{
+		"GITHUB_REFERENCE_MODE":                    "any",
+		"GITHUB_MAX_REFERENCES":                    "2",
+		"GITHUB_POLICY_ENFORCEMENT":                "LABEL_MISSING=warn,APPROVAL_MISSING=off",
+		"GITHUB_REPOSITORY_VISIBILITIES":           "private,internal",
+		"GITHUB_REPOSITORY_DENY_ARCHIVED":          "true",
+		"GITHUB_REPOSITORY_DENY_FORKS":             "true",
+		"GITHUB_REPOSITORY_TOPICS":                 "production-service",
+		"GITHUB_REPOSITORY_PROPERTIES":             "owner-team=payments",
+		"GITHUB_REPOSITORY_PROPERTY_LABELS":        "tier=critical:incident",
+		"GITHUB_REPOSITORY_CACHE_TTL":              "10m",
+		"GITHUB_DISCUSSION_CATEGORIES":             "Change Reviews,Incidents",
+		"GITHUB_DISCUSSION_ANSWERED":               "answered",
+		"GITHUB_DISCUSSION_ALLOW_LOCKED":           "true",
+		"GITHUB_DISCUSSION_MAX_AGE":                "24h",
+		"GITHUB_PROJECT":                           "my-org/5",
+		"GITHUB_PROJECT_ALLOWED_STATUSES":          "Approved,In Progress",
+		"GITHUB_WORKFLOW_RUN_COMPLETED_WINDOW":     "30m",
+		"GITHUB_WORKFLOW_PATHS":                    ".github/workflows/deploy.yml",
+		"GITHUB_WORKFLOW_BRANCHES":                 "main,release",
+		"GITHUB_DEPLOYMENT_ENVIRONMENTS":           "production",
+		"GITHUB_DEPLOYMENT_ALLOWED_STATES":         "waiting,success",
+		"GITHUB_PULL_REQUEST_MERGED_WINDOW":        "24h",
+		"GITHUB_PULL_REQUEST_BASE_BRANCHES":        "main",
+		"GITHUB_ISSUE_REQUIRE_LINKED_PULL_REQUEST": "true",
+		"GITHUB_PULL_REQUEST_REQUIRE_LINKED_ISSUE": "true",
+		"GITHUB_ISSUE_MILESTONES":                  "Q3 Incidents",
+		"GITHUB_ISSUE_REQUIRE_OPEN_MILESTONE":      "true",
+		"GITHUB_ISSUE_TYPES":                       "Incident,Change",
+		"GITHUB_ISSUE_REQUIRED_SECTIONS":           "Impact,Rollback plan",
+		"GITHUB_ISSUE_BODY_PATTERNS":               "TICKET-[0-9]+",
+		"GITHUB_ISSUE_AUTHOR_TEAMS":                "my-org/oncall",
+		"GITHUB_ISSUE_ASSIGNEE_TEAMS":              "my-org/sre",
+		"GITHUB_TEAM_CACHE_TTL":                    "10m",
+		"GITHUB_APPROVAL_TEAMS":                    "my-org/sre,my-org/oncall",
+		"GITHUB_APPROVAL_REACTION":                 "rocket",
+		"GITHUB_APPROVAL_COMMENT":                  "/lgtm",
+		"GITHUB_DEGRADED_MODE":                     "true",
+		"GITHUB_DEGRADED_WINDOW":                   "30m",
+		"GITHUB_DEGRADED_ALLOWLIST":                "https://github.com/my-org/runbooks/issues/1",
+	})))
+	set = got.ToFlags(set)
+	if err := set.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Policy{
+		ReferenceMode: referenceModeAny,
+		MaxReferences: 2,
+		Enforcement:   []string{"LABEL_MISSING=warn", "APPROVAL_MISSING=off"},
+
+		RepositoryVisibilities:   []string{"private", "internal"},
+		RepositoryDenyArchived:   true,
+		RepositoryDenyForks:      true,
+		RepositoryTopics:         []string{"production-service"},
+		RepositoryProperties:     []string{"owner-team=payments"},
+		RepositoryPropertyLabels: []string{"tier=critical:incident"},
+		RepositoryCacheTTL:       10 * time.Minute,
+
+		DiscussionCategories:   []string{"Change Reviews", "Incidents"},
+		DiscussionAnswered:     discussionAnsweredAnswered,
+		DiscussionAllowLocked:  true,
+		DiscussionMaxAge:       24 * time.Hour,
+		Project:                "my-org/5",
+		ProjectAllowedStatuses: []string{"Approved", "In Progress"},
+
+		WorkflowRunCompletedWindow: 30 * time.Minute,
+		WorkflowPaths:              []string{".github/workflows/deploy.yml"},
+		WorkflowBranches:           []string{"main", "release"},
+
+		DeploymentEnvironments:  []string{"production"},
+		DeploymentAllowedStates: []string{"waiting", "success"},
+
+		PullRequestMergedWindow: 24 * time.Hour,
+		PullRequestBaseBranches: []string{"main"},
+
+		IssueRequireLinkedPullRequest: true,
+		PullRequestRequireLinkedIssue: true,
+
+		IssueMilestones:           []string{"Q3 Incidents"},
+		IssueRequireOpenMilestone: true,
+		IssueTypes:                []string{"Incident", "Change"},
+
+		IssueRequiredSections: []string{"Impact", "Rollback plan"},
+		IssueBodyPatterns:     []string{"TICKET-[0-9]+"},
+
+		IssueAuthorTeams:   []string{"my-org/oncall"},
+		IssueAssigneeTeams: []string{"my-org/sre"},
+		TeamCacheTTL:       10 * time.Minute,
+
+		ApprovalTeams:    []string{"my-org/sre", "my-org/oncall"},
+		ApprovalReaction: "rocket",
+		ApprovalComment:  "/lgtm",
+
+		DegradedMode:      true,
+		DegradedWindow:    30 * time.Minute,
+		DegradedAllowlist: []string{"https://github.com/my-org/runbooks/issues/1"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Policy unexpected diff (-want,+got):\n%s", diff)
+	}
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		policy     *Policy
+		wantPolicy *Policy
+		wantErr    string
+	}{
+		{
+			name:   "defaults",
+			policy: &Policy{},
+			wantPolicy: &Policy{
+				ReferenceMode:      referenceModeAll,
+				MaxReferences:      defaultMaxReferences,
+				RepositoryCacheTTL: defaultRepositoryCacheTTL,
+				DiscussionAnswered: discussionAnsweredAny,
+				ProjectStatusField: defaultProjectStatusField,
+				TeamCacheTTL:       defaultTeamCacheTTL,
+				ApprovalReaction:   defaultApprovalReaction,
+				ApprovalComment:    defaultApprovalComment,
+				DegradedWindow:     defaultDegradedWindow,
+			},
+		},
+		{
+			name:    "invalid_reference_mode",
+			policy:  &Policy{ReferenceMode: "some"},
+			wantErr: `GITHUB_REFERENCE_MODE must be one of "all" or "any", got "some"`,
+		},
+		{
+			name:    "negative_max_references",
+			policy:  &Policy{MaxReferences: -1},
+			wantErr: "GITHUB_MAX_REFERENCES must be positive, got -1",
+		},
+		{
+			name:    "invalid_enforcement",
+			policy:  &Policy{Enforcement: []string{"ISSUE_CLOSED=warn"}},
+			wantErr: `invalid GITHUB_POLICY_ENFORCEMENT: enforcement reason "ISSUE_CLOSED" must be one of`,
+		},
+		{
+			name:    "invalid_repository_visibility",
+			policy:  &Policy{RepositoryVisibilities: []string{"secret"}},
+			wantErr: `GITHUB_REPOSITORY_VISIBILITIES must be in`,
+		},
+		{
+			name:    "invalid_repository_property",
+			policy:  &Policy{RepositoryProperties: []string{"tier"}},
+			wantErr: `invalid GITHUB_REPOSITORY_PROPERTIES: custom property "tier" must be in the format name=value`,
+		},
+		{
+			name:    "invalid_repository_property_label",
+			policy:  &Policy{RepositoryPropertyLabels: []string{"tier=critical"}},
+			wantErr: `invalid GITHUB_REPOSITORY_PROPERTY_LABELS: rule "tier=critical" must be in the format name=value:label`,
+		},
+		{
+			name:    "negative_repository_cache_ttl",
+			policy:  &Policy{RepositoryCacheTTL: -time.Minute},
+			wantErr: "GITHUB_REPOSITORY_CACHE_TTL must be positive",
+		},
+		{
+			name:    "invalid_discussion_answered",
+			policy:  &Policy{DiscussionAnswered: "maybe"},
+			wantErr: `GITHUB_DISCUSSION_ANSWERED must be one of "any", "answered" or "unanswered", got "maybe"`,
+		},
+		{
+			name:    "invalid_project",
+			policy:  &Policy{Project: "my-org"},
+			wantErr: `invalid GITHUB_PROJECT: project "my-org" must be in the format owner/number`,
+		},
+		{
+			name:    "negative_discussion_max_age",
+			policy:  &Policy{DiscussionMaxAge: -time.Hour},
+			wantErr: "GITHUB_DISCUSSION_MAX_AGE must be positive",
+		},
+		{
+			name:    "negative_workflow_run_completed_window",
+			policy:  &Policy{WorkflowRunCompletedWindow: -time.Minute},
+			wantErr: "GITHUB_WORKFLOW_RUN_COMPLETED_WINDOW must be positive",
+		},
+		{
+			name:    "negative_pull_request_merged_window",
+			policy:  &Policy{PullRequestMergedWindow: -time.Hour},
+			wantErr: "GITHUB_PULL_REQUEST_MERGED_WINDOW must be positive",
+		},
+		{
+			name:    "invalid_approval_team",
+			policy:  &Policy{ApprovalTeams: []string{"sre"}},
+			wantErr: `GITHUB_APPROVAL_TEAMS team "sre" must be in the format org/team-slug`,
+		},
+		{
+			name:    "invalid_issue_body_pattern",
+			policy:  &Policy{IssueBodyPatterns: []string{"TICKET-[0-9"}},
+			wantErr: `GITHUB_ISSUE_BODY_PATTERNS pattern "TICKET-[0-9" is invalid`,
+		},
+		{
+			name:    "invalid_issue_author_team",
+			policy:  &Policy{IssueAuthorTeams: []string{"my-org/"}},
+			wantErr: `GITHUB_ISSUE_AUTHOR_TEAMS team "my-org/" must be in the format org/team-slug`,
+		},
+		{
+			name:    "negative_team_cache_ttl",
+			policy:  &Policy{TeamCacheTTL: -time.Minute},
+			wantErr: "GITHUB_TEAM_CACHE_TTL must be positive",
+		},
+		{
+			name:    "invalid_approval_reaction",
+			policy:  &Policy{ApprovalReaction: "thumbsup"},
+			wantErr: `GITHUB_APPROVAL_REACTION must be one of`,
+		},
+		{
+			name:    "invalid_deployment_allowed_state",
+			policy:  &Policy{DeploymentAllowedStates: []string{"approved"}},
+			wantErr: `GITHUB_DEPLOYMENT_ALLOWED_STATES must be in`,
+		},
+		{
+			name:    "negative_degraded_window",
+			policy:  &Policy{DegradedMode: true, DegradedWindow: -time.Minute},
+			wantErr: "GITHUB_DEGRADED_WINDOW must be positive",
+		},
+		{
+			name:    "degraded_allowlist_without_mode",
+			policy:  &Policy{DegradedAllowlist: []string{"https://github.com/my-org/runbooks/issues/1"}},
+			wantErr: "GITHUB_DEGRADED_ALLOWLIST requires GITHUB_DEGRADED_MODE",
+		},
+		{
+			name:    "invalid_degraded_allowlist",
+			policy:  &Policy{DegradedMode: true, DegradedAllowlist: []string{"my-org/runbooks#1"}},
+			wantErr: `GITHUB_DEGRADED_ALLOWLIST reference "my-org/runbooks#1" must be a https://github.com/ URL`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.policy.Validate()
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if tc.wantPolicy != nil {
+				if diff := cmp.Diff(tc.wantPolicy, tc.policy); diff != "" {
+					t.Errorf("Policy unexpected diff (-want,+got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
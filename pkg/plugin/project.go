@@ -0,0 +1,249 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/abcxyz/pkg/githubauth"
+)
+
+const (
+	projectItemURLPatternRegExp = `^https:\/\/github.com\/(orgs|users)\/[a-zA-Z0-9-]+\/projects\/[0-9]+(\/views\/[0-9]+)?$`
+
+	// defaultProjectStatusField is the name of the single-select field GitHub
+	// creates for new projects.
+	defaultProjectStatusField = "Status"
+
+	// maxProjectItems bounds the number of projects inspected for an issue.
+	maxProjectItems = 50
+
+	// issueProjectItemsQuery fetches the projects the issue is in, with the
+	// value of the status field in each of them.
+	issueProjectItemsQuery = `query($owner: String!, $repo: String!, $number: Int!, $field: String!, $first: Int!) {
+  repository(owner: $owner, name: $repo) {
+    issue(number: $number) {
+      projectItems(first: $first, includeArchived: false) {
+        nodes {
+          project {
+            number
+            owner {
+              login
+            }
+          }
+          fieldValueByName(name: $field) {
+            ... on ProjectV2ItemFieldSingleSelectValue {
+              name
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+)
+
+// projectRef identifies a GitHub project (v2) by its owner and number.
+type projectRef struct {
+	Owner  string
+	Number int
+}
+
+// String returns the project in the "owner/number" format.
+func (p *projectRef) String() string {
+	return p.Owner + "/" + strconv.Itoa(p.Number)
+}
+
+// parseProjectRef parses a project in the "owner/number" format.
+func parseProjectRef(s string) (*projectRef, error) {
+	owner, number, ok := strings.Cut(s, "/")
+	if !ok || owner == "" {
+		return nil, fmt.Errorf("project %q must be in the format owner/number", s)
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil || n <= 0 {
+		return nil, fmt.Errorf("project %q must be in the format owner/number", s)
+	}
+	return &projectRef{Owner: owner, Number: n}, nil
+}
+
+// issueProjectItemsQueryResult is the response data of issueProjectItemsQuery.
+type issueProjectItemsQueryResult struct {
+	Repository *struct {
+		Issue *struct {
+			ProjectItems struct {
+				Nodes []*struct {
+					Project struct {
+						Number int `json:"number"`
+						Owner  struct {
+							Login string `json:"login"`
+						} `json:"owner"`
+					} `json:"project"`
+					FieldValueByName *struct {
+						Name string `json:"name"`
+					} `json:"fieldValueByName"`
+				} `json:"nodes"`
+			} `json:"projectItems"`
+		} `json:"issue"`
+	} `json:"repository"`
+}
+
+// MatchProjectItem parses the issue and project from the provided project item
+// URL and validates the issue, requiring it to be in that project, or in the
+// required project if the policy has one. Citing another project than the
+// required one is a violation, and when it isn't enforced the issue is still
+// validated against the required project.
+func (v *Validator) MatchProjectItem(ctx context.Context, itemURL string) (*pluginGitHubIssue, error) {
+	project, info, err := parseProjectItemInfoFromURL(itemURL)
+	if err != nil {
+		return nil, invalidf(ReasonReferenceMalformed, "failed to parse project item url: %w", err)
+	}
+
+	if v.policy.Project != "" {
+		want, err := parseProjectRef(v.policy.Project)
+		if err != nil {
+			return nil, fmt.Errorf("invalid project policy: %w", err)
+		}
+		if !strings.EqualFold(want.Owner, project.Owner) || want.Number != project.Number {
+			if err := v.enforce(ctx, invalidf(ReasonProjectNotAllowed, "project %s is not the required project %s", project, want)); err != nil {
+				return nil, err
+			}
+		}
+		project = want
+	}
+
+	return v.matchIssue(ctx, info, project)
+}
+
+// validateProjectItem verifies the issue is an item of the project and its
+// status field has one of the allowed values, and records the status.
+func (v *Validator) validateProjectItem(ctx context.Context, pi *pluginGitHubIssue, project *projectRef) error {
+	t, err := v.getProjectAccessToken(ctx, pi.RepoName)
+	if err != nil {
+		return fmt.Errorf("failed to get access token: %w", err)
+	}
+	c := v.client.WithAuthToken(t)
+
+	field := v.policy.ProjectStatusField
+	if field == "" {
+		field = defaultProjectStatusField
+	}
+
+	var result issueProjectItemsQueryResult
+	if err := queryGraphQL(ctx, c, issueProjectItemsQuery, map[string]any{
+		"owner":  pi.Owner,
+		"repo":   pi.RepoName,
+		"number": pi.IssueNumber,
+		"field":  field,
+		"first":  maxProjectItems,
+	}, &result); err != nil {
+		return fmt.Errorf("failed to get issue project items: %w", err)
+	}
+	if result.Repository == nil || result.Repository.Issue == nil {
+		return invalidf(ReasonReferenceNotFound, "issue not found")
+	}
+
+	for _, item := range result.Repository.Issue.ProjectItems.Nodes {
+		if item.Project.Number != project.Number || !strings.EqualFold(item.Project.Owner.Login, project.Owner) {
+			continue
+		}
+
+		pi.Project = project.String()
+		if item.FieldValueByName != nil {
+			pi.ProjectStatus = item.FieldValueByName.Name
+		}
+
+		if allowed := v.policy.ProjectAllowedStatuses; len(allowed) > 0 &&
+			!slices.ContainsFunc(allowed, func(s string) bool { return strings.EqualFold(s, pi.ProjectStatus) }) {
+			return invalidf(ReasonProjectStatusNotAllowed, "project %s %s %q is not one of %q",
+				project, field, pi.ProjectStatus, allowed)
+		}
+		return nil
+	}
+
+	return invalidf(ReasonProjectNotAllowed, "issue is not in project %s", project)
+}
+
+// getProjectAccessToken gets an access token with issue and organization
+// project read permission, which is required to list the projects of an issue.
+func (v *Validator) getProjectAccessToken(ctx context.Context, repoName string) (string, error) {
+	tr := &githubauth.TokenRequest{
+		Repositories: []string{repoName},
+		Permissions: map[string]string{
+			"issues":                "read",
+			"organization_projects": "read",
+		},
+	}
+
+	resp, err := v.githubInstallation.AccessToken(ctx, tr)
+	if err != nil {
+		return "", fmt.Errorf("failed to get access token: %w", err)
+	}
+	return resp, nil
+}
+
+// parseProjectItemInfoFromURL parses the project and the issue from a project
+// item URL. GitHub includes the issue of the open item in the "issue" query
+// parameter as "owner|repo|number".
+func parseProjectItemInfoFromURL(itemURL string) (*projectRef, *pluginGitHubIssue, error) {
+	u, err := url.Parse(itemURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse provided project item url: %w", err)
+	}
+
+	base := (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}).String()
+	if match, _ := regexp.MatchString(projectItemURLPatternRegExp, base); !match {
+		return nil, nil, fmt.Errorf("invalid project item url, %s", referenceHint(itemURL, referenceKindProjectItem))
+	}
+
+	arr := strings.Split(u.Path, "/")
+	// len(arr) is not checked here as regexp.MatchString already covers this.
+	number, err := strconv.Atoi(arr[4])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to convert project number %s to int: %w", arr[4], err)
+	}
+	project := &projectRef{Owner: arr[2], Number: number}
+
+	issue := u.Query().Get("issue")
+	if issue == "" {
+		return nil, nil, fmt.Errorf("project item url must reference an issue, open the item and copy the url, or use the issue url instead")
+	}
+	parts := strings.Split(issue, "|")
+	if len(parts) != 3 {
+		return nil, nil, fmt.Errorf("invalid issue %q in project item url, expected owner|repo|number", issue)
+	}
+	issueNumber, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to convert issueNumber %s to int: %w", parts[2], err)
+	}
+	// The owner and repo are used in API paths, so they must be valid like in
+	// issue URLs.
+	issueURL := fmt.Sprintf("https://github.com/%s/%s/issues/%d", parts[0], parts[1], issueNumber)
+	if match, _ := regexp.MatchString(issueURLPatternRegExp, issueURL); !match || parts[0] == "" || parts[1] == "" {
+		return nil, nil, fmt.Errorf("invalid issue %q in project item url, expected a valid owner and repo", issue)
+	}
+
+	return project, &pluginGitHubIssue{
+		Owner:       parts[0],
+		RepoName:    parts[1],
+		IssueNumber: issueNumber,
+	}, nil
+}
@@ -0,0 +1,273 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v55/github"
+
+	"github.com/abcxyz/pkg/testutil"
+)
+
+const testGitHubProjectItemURL = "https://github.com/orgs/test-owner/projects/5/views/1?pane=issue&issue=test-owner%7Ctest-repo%7C1"
+
+// testHandleIssueAndGraphQL returns a fake http func that serves the test
+// issue over REST and the given response to all GraphQL queries.
+func testHandleIssueAndGraphQL(tb testing.TB, issue []byte, graphQLResponse string) func(w http.ResponseWriter, r *http.Request) {
+	tb.Helper()
+	issueHandler := testHandleIssueReturn(tb, issue)
+	graphQLHandler := testHandleGraphQL(tb, func(vars map[string]any) string {
+		return graphQLResponse
+	})
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/graphql" {
+			graphQLHandler(w, r)
+			return
+		}
+		issueHandler(w, r)
+	}
+}
+
+func TestMatchProjectItem(t *testing.T) {
+	t.Parallel()
+
+	projectItems := func(owner string, number int, status string) string {
+		return fmt.Sprintf(`{"data": {"repository": {"issue": {"projectItems": {"nodes": [{"project": {"number": %d, "owner": {"login": %q}}, "fieldValueByName": {"name": %q}}]}}}}}`,
+			number, owner, status)
+	}
+
+	wantInfo := func(status string) *pluginGitHubIssue {
+		return &pluginGitHubIssue{
+			Owner:         testIssueOwner,
+			RepoName:      testIssueRepoName,
+			IssueNumber:   testExistIssueNumber,
+			SnapshotHash:  testOpenIssueSnapshotHash,
+			Project:       "test-owner/5",
+			ProjectStatus: status,
+		}
+	}
+
+	cases := []struct {
+		name         string
+		itemURL      string
+		policy       *Policy
+		projectItems string
+		want         *pluginGitHubIssue
+		wantErr      string
+		wantInvalid  bool
+	}{
+		{
+			name:         "success",
+			itemURL:      testGitHubProjectItemURL,
+			policy:       &Policy{},
+			projectItems: projectItems("test-owner", 5, "In Progress"),
+			want:         wantInfo("In Progress"),
+		},
+		{
+			name:        "missing_issue",
+			itemURL:     "https://github.com/orgs/test-owner/projects/5",
+			policy:      &Policy{},
+			wantErr:     "project item url must reference an issue",
+			wantInvalid: true,
+		},
+		{
+			name:        "not_required_project",
+			itemURL:     testGitHubProjectItemURL,
+			policy:      &Policy{Project: "test-owner/6"},
+			wantErr:     "project test-owner/5 is not the required project test-owner/6",
+			wantInvalid: true,
+		},
+		{
+			name:         "not_required_project_warned",
+			itemURL:      testGitHubProjectItemURL,
+			policy:       &Policy{Project: "test-owner/6", Enforcement: []string{"PROJECT_NOT_ALLOWED=warn"}},
+			projectItems: projectItems("test-owner", 6, "In Progress"),
+			want: &pluginGitHubIssue{
+				Owner:         testIssueOwner,
+				RepoName:      testIssueRepoName,
+				IssueNumber:   testExistIssueNumber,
+				SnapshotHash:  testOpenIssueSnapshotHash,
+				Project:       "test-owner/6",
+				ProjectStatus: "In Progress",
+			},
+		},
+		{
+			name:         "not_required_project_warned_not_in_required_project",
+			itemURL:      testGitHubProjectItemURL,
+			policy:       &Policy{Project: "test-owner/6", Enforcement: []string{"PROJECT_NOT_ALLOWED=warn"}},
+			projectItems: projectItems("test-owner", 5, "In Progress"),
+			want: &pluginGitHubIssue{
+				Owner:        testIssueOwner,
+				RepoName:     testIssueRepoName,
+				IssueNumber:  testExistIssueNumber,
+				SnapshotHash: testOpenIssueSnapshotHash,
+			},
+		},
+		{
+			name:         "not_in_project",
+			itemURL:      testGitHubProjectItemURL,
+			policy:       &Policy{},
+			projectItems: projectItems("other-owner", 5, "In Progress"),
+			want: &pluginGitHubIssue{
+				Owner:        testIssueOwner,
+				RepoName:     testIssueRepoName,
+				IssueNumber:  testExistIssueNumber,
+				SnapshotHash: testOpenIssueSnapshotHash,
+			},
+			wantErr:     "issue is not in project test-owner/5",
+			wantInvalid: true,
+		},
+		{
+			name:         "status_allowed",
+			itemURL:      testGitHubProjectItemURL,
+			policy:       &Policy{ProjectAllowedStatuses: []string{"approved"}},
+			projectItems: projectItems("test-owner", 5, "Approved"),
+			want:         wantInfo("Approved"),
+		},
+		{
+			name:         "status_not_allowed",
+			itemURL:      testGitHubProjectItemURL,
+			policy:       &Policy{ProjectAllowedStatuses: []string{"Approved"}},
+			projectItems: projectItems("test-owner", 5, "Todo"),
+			want:         wantInfo("Todo"),
+			wantErr:      `project test-owner/5 Status "Todo" is not one of ["Approved"]`,
+			wantInvalid:  true,
+		},
+		{
+			name:         "graphql_error",
+			itemURL:      testGitHubProjectItemURL,
+			policy:       &Policy{},
+			projectItems: `{"errors": [{"type": "INTERNAL", "message": "boom"}]}`,
+			wantErr:      "failed to get issue project items",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			installation := testGitHubInstallation(t, http.StatusCreated)
+			hc := newTestServer(t, testHandleIssueAndGraphQL(t, []byte(`{"state": "open"}`), tc.projectItems))
+
+			v := NewValidator(github.NewClient(hc), installation, tc.policy)
+			got, err := v.MatchProjectItem(t.Context(), tc.itemURL)
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if got, want := errors.Is(err, errInvalidJustification), tc.wantInvalid; got != want {
+				t.Errorf("errors.Is(%v, errInvalidJustification) = %t, want %t", err, got, want)
+			}
+			if tc.wantErr == "" || tc.want != nil {
+				if diff := cmp.Diff(tc.want, got); diff != "" {
+					t.Errorf("MatchProjectItem() unexpected diff (-want,+got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchIssue_ProjectPolicy(t *testing.T) {
+	t.Parallel()
+
+	installation := testGitHubInstallation(t, http.StatusCreated)
+	hc := newTestServer(t, testHandleIssueAndGraphQL(t, []byte(`{"state": "open"}`),
+		`{"data": {"repository": {"issue": {"projectItems": {"nodes": []}}}}}`))
+
+	v := NewValidator(github.NewClient(hc), installation, &Policy{Project: "test-owner/5"})
+	_, err := v.MatchIssue(t.Context(), testGitHubIssueURL)
+	if diff := testutil.DiffErrString(err, "issue is not in project test-owner/5"); diff != "" {
+		t.Error(diff)
+	}
+	if !errors.Is(err, errInvalidJustification) {
+		t.Errorf("errors.Is(%v, errInvalidJustification) = false, want true", err)
+	}
+}
+
+func TestParseProjectItemInfoFromURL(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		itemURL     string
+		wantProject *projectRef
+		wantIssue   *pluginGitHubIssue
+		wantErr     string
+	}{
+		{
+			name:        "org_project_view",
+			itemURL:     testGitHubProjectItemURL,
+			wantProject: &projectRef{Owner: "test-owner", Number: 5},
+			wantIssue:   &pluginGitHubIssue{Owner: "test-owner", RepoName: "test-repo", IssueNumber: 1},
+		},
+		{
+			name:        "user_project",
+			itemURL:     "https://github.com/users/someone/projects/2?pane=issue&issue=other%7Crepo%7C42",
+			wantProject: &projectRef{Owner: "someone", Number: 2},
+			wantIssue:   &pluginGitHubIssue{Owner: "other", RepoName: "repo", IssueNumber: 42},
+		},
+		{
+			name:    "not_project",
+			itemURL: "https://github.com/test-owner/test-repo/issues/1",
+			wantErr: "invalid project item url, expected a URL like",
+		},
+		{
+			name:    "malformed_issue",
+			itemURL: "https://github.com/orgs/test-owner/projects/5?issue=test-owner%7C1",
+			wantErr: "expected owner|repo|number",
+		},
+		{
+			name:    "issue_invalid_owner",
+			itemURL: "https://github.com/orgs/test-owner/projects/5?issue=..%7Crepo%7C1",
+			wantErr: `invalid issue "..|repo|1" in project item url, expected a valid owner and repo`,
+		},
+		{
+			name:    "issue_invalid_repo",
+			itemURL: "https://github.com/orgs/test-owner/projects/5?issue=test-owner%7Crepo%2Fissues%7C1",
+			wantErr: `invalid issue "test-owner|repo/issues|1" in project item url, expected a valid owner and repo`,
+		},
+		{
+			name:    "issue_empty_owner",
+			itemURL: "https://github.com/orgs/test-owner/projects/5?issue=%7Crepo%7C1",
+			wantErr: `invalid issue "|repo|1" in project item url, expected a valid owner and repo`,
+		},
+		{
+			name:    "issue_not_int",
+			itemURL: "https://github.com/orgs/test-owner/projects/5?issue=test-owner%7Crepo%7Cabc",
+			wantErr: "failed to convert issueNumber abc to int",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotProject, gotIssue, err := parseProjectItemInfoFromURL(tc.itemURL)
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tc.wantProject, gotProject); diff != "" {
+				t.Errorf("project unexpected diff (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantIssue, gotIssue); diff != "" {
+				t.Errorf("issue unexpected diff (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+)
+
+// customPropertyValue is a custom property value of a repository. The value
+// is a string, a list of strings for multi select properties, or null when
+// unset.
+type customPropertyValue struct {
+	PropertyName string          `json:"property_name"`
+	Value        json.RawMessage `json:"value"`
+}
+
+// getCustomProperties gets the custom property values of the repository.
+// Repositories of users, which don't support custom properties, have none.
+func getCustomProperties(ctx context.Context, c *github.Client, owner, repoName string) (map[string][]string, error) {
+	// The client library doesn't support custom properties.
+	req, err := c.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/properties/values", owner, repoName), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create custom properties request: %w", err)
+	}
+	var values []*customPropertyValue
+	resp, err := c.Do(ctx, req, &values)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get custom properties: %w", err)
+	}
+
+	properties := make(map[string][]string, len(values))
+	for _, v := range values {
+		var s string
+		if err := json.Unmarshal(v.Value, &s); err == nil {
+			if s != "" {
+				properties[v.PropertyName] = []string{s}
+			}
+			continue
+		}
+		var list []string
+		if err := json.Unmarshal(v.Value, &list); err != nil {
+			return nil, fmt.Errorf("failed to parse custom property %s value %s: %w", v.PropertyName, v.Value, err)
+		}
+		if len(list) > 0 {
+			properties[v.PropertyName] = list
+		}
+	}
+	return properties, nil
+}
+
+// HasProperty reports whether the custom property has the value, case
+// insensitively.
+func (r *pluginGitHubRepository) HasProperty(name, value string) bool {
+	return slices.ContainsFunc(r.Properties[name], func(v string) bool { return strings.EqualFold(v, value) })
+}
+
+// parsePropertyCondition parses a custom property condition in the
+// "name=value" format.
+func parsePropertyCondition(s string) (name, value string, err error) {
+	name, value, ok := strings.Cut(s, "=")
+	if !ok || name == "" || value == "" {
+		return "", "", fmt.Errorf("custom property %q must be in the format name=value", s)
+	}
+	return name, value, nil
+}
+
+// propertyLabelRule requires issues in repositories with the custom property
+// value to have the label.
+type propertyLabelRule struct {
+	Name  string
+	Value string
+	Label string
+}
+
+// parsePropertyLabelRule parses a rule in the "name=value:label" format.
+func parsePropertyLabelRule(s string) (*propertyLabelRule, error) {
+	condition, label, ok := strings.Cut(s, ":")
+	if !ok || label == "" {
+		return nil, fmt.Errorf("rule %q must be in the format name=value:label", s)
+	}
+	name, value, err := parsePropertyCondition(condition)
+	if err != nil {
+		return nil, fmt.Errorf("rule %q must be in the format name=value:label", s)
+	}
+	return &propertyLabelRule{Name: name, Value: value, Label: label}, nil
+}
+
+// validateIssuePropertyLabels verifies the issue has the labels required by
+// the custom property values of its repository.
+func (v *Validator) validateIssuePropertyLabels(ctx context.Context, pi *pluginGitHubIssue, issue *github.Issue) error {
+	if len(v.policy.RepositoryPropertyLabels) == 0 {
+		return nil
+	}
+
+	repo, err := v.lookupRepository(ctx, pi.Owner, pi.RepoName)
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, s := range v.policy.RepositoryPropertyLabels {
+		rule, err := parsePropertyLabelRule(s)
+		if err != nil {
+			return fmt.Errorf("invalid repository property label policy: %w", err)
+		}
+		if !repo.HasProperty(rule.Name, rule.Value) {
+			continue
+		}
+		if !slices.ContainsFunc(issue.Labels, func(l *github.Label) bool { return strings.EqualFold(l.GetName(), rule.Label) }) &&
+			!slices.Contains(missing, rule.Label) {
+			missing = append(missing, rule.Label)
+		}
+	}
+	if len(missing) > 0 {
+		return invalidf(ReasonLabelMissing, "issues in repository %s/%s require labels %q", repo.Owner, repo.RepoName, missing)
+	}
+	return nil
+}
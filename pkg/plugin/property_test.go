@@ -0,0 +1,237 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v55/github"
+
+	jvspb "github.com/abcxyz/jvs/apis/v0"
+	"github.com/abcxyz/pkg/testutil"
+)
+
+func TestParsePropertyLabelRule(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		rule    string
+		want    *propertyLabelRule
+		wantErr string
+	}{
+		{
+			name: "valid",
+			rule: "tier=critical:incident",
+			want: &propertyLabelRule{Name: "tier", Value: "critical", Label: "incident"},
+		},
+		{
+			name:    "missing_label",
+			rule:    "tier=critical:",
+			wantErr: `rule "tier=critical:" must be in the format name=value:label`,
+		},
+		{
+			name:    "missing_value",
+			rule:    "tier:incident",
+			wantErr: `rule "tier:incident" must be in the format name=value:label`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parsePropertyLabelRule(tc.rule)
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("parsePropertyLabelRule() unexpected diff (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateIssuePropertyLabels(t *testing.T) {
+	t.Parallel()
+
+	labels := func(names ...string) []*github.Label {
+		var labels []*github.Label
+		for _, n := range names {
+			labels = append(labels, &github.Label{Name: github.String(n)})
+		}
+		return labels
+	}
+
+	cases := []struct {
+		name    string
+		rules   []string
+		labels  []*github.Label
+		wantErr string
+	}{
+		{
+			name:   "no_rules",
+			labels: labels(),
+		},
+		{
+			name:   "label_present",
+			rules:  []string{"tier=critical:incident"},
+			labels: labels("Incident"),
+		},
+		{
+			name:   "property_not_matching",
+			rules:  []string{"tier=low:incident"},
+			labels: labels(),
+		},
+		{
+			name:    "labels_missing",
+			rules:   []string{"tier=critical:incident", "owner-team=payments:pci", "tier=critical:sev1"},
+			labels:  labels("sev1"),
+			wantErr: `issues in repository test-owner/test-repo require labels ["incident" "pci"]`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			v := NewValidator(nil, nil, &Policy{
+				RepositoryPropertyLabels: tc.rules,
+				RepositoryCacheTTL:       time.Minute,
+			})
+			v.repositories.Set("test-owner/test-repo", &pluginGitHubRepository{
+				Owner:    testIssueOwner,
+				RepoName: testIssueRepoName,
+				Properties: map[string][]string{
+					"tier":       {"critical"},
+					"owner-team": {"payments"},
+				},
+			})
+
+			err := v.validateIssuePropertyLabels(t.Context(),
+				&pluginGitHubIssue{Owner: testIssueOwner, RepoName: testIssueRepoName, IssueNumber: 1},
+				&github.Issue{Labels: tc.labels})
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if tc.wantErr != "" && !errors.Is(err, errInvalidJustification) {
+				t.Errorf("errors.Is(%v, errInvalidJustification) = false, want true", err)
+			}
+		})
+	}
+}
+
+func TestValidate_PropertyLabelsOnly(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		issue     string
+		wantValid bool
+		wantError []string
+	}{
+		{
+			name:      "label_present",
+			issue:     `{"state": "open", "labels": [{"name": "incident"}]}`,
+			wantValid: true,
+		},
+		{
+			name:      "label_missing",
+			issue:     `{"state": "open"}`,
+			wantError: []string{`[LABEL_MISSING] invalid justification: issues in repository test-owner/test-repo require labels ["incident"]`},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			installation := testGitHubInstallation(t, http.StatusCreated)
+			issueHandler := testHandleIssueReturn(t, []byte(tc.issue))
+			hc := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case fmt.Sprintf("%s/%s/%s", issueRESTAPIPathPrefix, testIssueOwner, testIssueRepoName):
+					fmt.Fprintf(w, `{"name": %q, "owner": {"login": %q}, "visibility": "private"}`, testIssueRepoName, testIssueOwner)
+				case fmt.Sprintf("%s/%s/%s/properties/values", issueRESTAPIPathPrefix, testIssueOwner, testIssueRepoName):
+					fmt.Fprint(w, `[{"property_name": "tier", "value": "critical"}]`)
+				default:
+					issueHandler(w, r)
+				}
+			})
+
+			policy := &Policy{RepositoryPropertyLabels: []string{"tier=critical:incident"}}
+			p := &GitHubPlugin{
+				categories: map[string]*pluginCategory{
+					githubCategory: {
+						validator:        NewValidator(github.NewClient(hc), installation, policy),
+						repositoryPolicy: policy.hasRepositoryPolicy(),
+					},
+				},
+			}
+			got, err := p.Validate(t.Context(), &jvspb.ValidateJustificationRequest{
+				Justification: &jvspb.Justification{
+					Category: githubCategory,
+					Value:    testGitHubIssueURL,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Validate() unexpected error: %v", err)
+			}
+			if got, want := got.GetValid(), tc.wantValid; got != want {
+				t.Errorf("Validate() valid got %t, want %t", got, want)
+			}
+			if diff := cmp.Diff(tc.wantError, got.GetError()); diff != "" {
+				t.Errorf("Validate() errors unexpected diff (-want,+got):\n%s", diff)
+			}
+			if tc.wantValid {
+				if got, want := got.GetAnnotation()["github_repository_property_tier"], "critical"; got != want {
+					t.Errorf("github_repository_property_tier annotation got %q, want %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRepositoryAnnotations(t *testing.T) {
+	t.Parallel()
+
+	got := repositoryAnnotations(&pluginGitHubRepository{
+		Owner:      testIssueOwner,
+		RepoName:   testIssueRepoName,
+		Visibility: "internal",
+		Topics:     []string{"production-service"},
+		Properties: map[string][]string{
+			"tier":    {"critical"},
+			"regions": {"us", "eu"},
+		},
+	})
+	want := map[string]string{
+		respAnnotationKeyRepository:           "test-owner/test-repo",
+		respAnnotationKeyRepositoryVisibility: "internal",
+		respAnnotationKeyRepositoryArchived:   "false",
+		respAnnotationKeyRepositoryFork:       "false",
+		respAnnotationKeyRepositoryTopics:     "production-service",
+		"github_repository_property_tier":     "critical",
+		"github_repository_property_regions":  "us,eu",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("repositoryAnnotations() unexpected diff (-want,+got):\n%s", diff)
+	}
+}
@@ -0,0 +1,279 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/abcxyz/pkg/githubauth"
+)
+
+const (
+	pullRequestURLPatternRegExp = `^https:\/\/github.com\/([a-zA-Z0-9-]*)\/[a-zA-Z0-9-]*\/pull\/[0-9]+$`
+	commitURLPatternRegExp      = `^https:\/\/github.com\/([a-zA-Z0-9-]*)\/[a-zA-Z0-9-]*\/commit\/[0-9a-f]{7,40}$`
+	compareURLPatternRegExp     = `^https:\/\/github.com\/([a-zA-Z0-9-]*)\/[a-zA-Z0-9-]*\/compare\/.+\.\.\.?.+$`
+
+	// States of pull requests recorded in annotations.
+	pullRequestStateOpen   = "open"
+	pullRequestStateMerged = "merged"
+	pullRequestStateClosed = "closed"
+
+	// maxCommitPullRequests bounds the number of pull requests inspected for a
+	// commit.
+	maxCommitPullRequests = 20
+)
+
+// pluginGitHubPullRequest contains the required attribute parsed from the pull
+// request, commit or compare URL, and the attributes used in annotations.
+type pluginGitHubPullRequest struct {
+	Owner      string
+	RepoName   string
+	PullNumber int
+
+	// CommitSHA is the commit the pull request was resolved from. It is not
+	// set for pull request URLs.
+	CommitSHA string
+	State     string
+
+	// LinkedIssues are the URLs of the issues the pull request closes. It is
+	// only set when linked issues are required.
+	LinkedIssues []string
+}
+
+// URL returns the canonical URL of the pull request.
+func (pr *pluginGitHubPullRequest) URL() string {
+	return fmt.Sprintf("https://github.com/%s/%s/pull/%d", pr.Owner, pr.RepoName, pr.PullNumber)
+}
+
+// MatchPullRequest parses pull request info from provided pullRequestURL and
+// validates the pull request against the policy.
+func (v *Validator) MatchPullRequest(ctx context.Context, pullRequestURL string) (*pluginGitHubPullRequest, error) {
+	info, err := parsePullRequestInfoFromURL(pullRequestURL)
+	if err != nil {
+		return nil, invalidf(ReasonReferenceMalformed, "failed to parse pull request url: %w", err)
+	}
+
+	t, err := v.getPullRequestAccessToken(ctx, info.RepoName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+	c := v.client.WithAuthToken(t)
+
+	pr, resp, err := c.PullRequests.Get(ctx, info.Owner, info.RepoName, info.PullNumber)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return info, invalidf(ReasonReferenceNotFound, "pull request not found: %w", err)
+		}
+		return info, fmt.Errorf("failed to get pull request info: %w", err)
+	}
+
+	info.State = pullRequestState(pr)
+	if err := v.validatePullRequest(ctx, pr); err != nil {
+		return info, err
+	}
+	if v.policy.PullRequestRequireLinkedIssue && !v.ruleOff(ReasonLinkMissing, rulePullRequestRequireLinkedIssue) {
+		if err := v.enforce(ctx, v.validateLinkedIssues(ctx, info)); err != nil {
+			return info, err
+		}
+	}
+	return info, nil
+}
+
+// MatchCommit parses the commit from the provided commit or compare URL,
+// using the head of compare URLs, and validates that it belongs to a pull
+// request satisfying the policy.
+func (v *Validator) MatchCommit(ctx context.Context, commitURL string) (*pluginGitHubPullRequest, error) {
+	info, ref, err := parseCommitInfoFromURL(commitURL)
+	if err != nil {
+		return nil, invalidf(ReasonReferenceMalformed, "failed to parse commit url: %w", err)
+	}
+
+	t, err := v.getPullRequestAccessToken(ctx, info.RepoName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+	c := v.client.WithAuthToken(t)
+
+	sha, resp, err := c.Repositories.GetCommitSHA1(ctx, info.Owner, info.RepoName, ref, "")
+	if err != nil {
+		if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnprocessableEntity) {
+			return info, invalidf(ReasonReferenceNotFound, "commit %s not found: %w", ref, err)
+		}
+		return info, fmt.Errorf("failed to get commit info: %w", err)
+	}
+	info.CommitSHA = sha
+
+	prs, _, err := c.PullRequests.ListPullRequestsWithCommit(ctx, info.Owner, info.RepoName, sha,
+		&github.ListOptions{PerPage: maxCommitPullRequests})
+	if err != nil {
+		return info, fmt.Errorf("failed to list pull requests of commit: %w", err)
+	}
+	if len(prs) == 0 {
+		return info, invalidf(ReasonCommitWithoutPullRequest, "commit %s is not associated with any pull request", sha)
+	}
+
+	// Accept the first pull request satisfying the policy, and report why
+	// each of them didn't otherwise. The policy violations which are not
+	// enforced are only reported for the accepted pull request.
+	var merr error
+	for _, pr := range prs {
+		candidate, warnings, err := v.matchCommitPullRequest(ctx, info, pr)
+		if err != nil {
+			merr = errors.Join(merr, fmt.Errorf("pull request #%d: %w", pr.GetNumber(), err))
+			continue
+		}
+		addPolicyWarnings(ctx, warnings...)
+		return candidate, nil
+	}
+	return info, invalidf(ReasonCommitWithoutPullRequest, "commit %s has no pull request satisfying the policy: %w", sha, merr)
+}
+
+// matchCommitPullRequest validates the pull request associated with the
+// commit, and returns it along with the policy violations which were not
+// enforced.
+func (v *Validator) matchCommitPullRequest(ctx context.Context, info *pluginGitHubPullRequest, pr *github.PullRequest) (*pluginGitHubPullRequest, []error, error) {
+	ctx, warnings := withPolicyWarnings(ctx)
+	if err := v.validatePullRequest(ctx, pr); err != nil {
+		return nil, nil, err
+	}
+	candidate := *info
+	candidate.PullNumber = pr.GetNumber()
+	candidate.State = pullRequestState(pr)
+	if v.policy.PullRequestRequireLinkedIssue && !v.ruleOff(ReasonLinkMissing, rulePullRequestRequireLinkedIssue) {
+		if err := v.enforce(ctx, v.validateLinkedIssues(ctx, &candidate)); err != nil {
+			return nil, nil, err
+		}
+	}
+	return &candidate, warnings.list(), nil
+}
+
+// validatePullRequest verifies the pull request is open, or was merged within
+// the configured window, and targets an allowed base branch if enforced.
+func (v *Validator) validatePullRequest(ctx context.Context, pr *github.PullRequest) error {
+	switch pullRequestState(pr) {
+	case pullRequestStateOpen:
+	case pullRequestStateMerged:
+		window := v.policy.PullRequestMergedWindow
+		if window <= 0 {
+			return invalidf(ReasonPullRequestClosed, "pull request is merged, please make sure to use an open pull request")
+		}
+		if time.Since(pr.GetMergedAt().Time) > window {
+			return invalidf(ReasonPullRequestClosed, "pull request was merged more than %s ago", window)
+		}
+	default:
+		return invalidf(ReasonPullRequestClosed, "pull request is closed, please make sure to use an open pull request")
+	}
+
+	if allowed := v.policy.PullRequestBaseBranches; len(allowed) > 0 && !slices.Contains(allowed, pr.GetBase().GetRef()) {
+		return v.enforce(ctx, violationf(rulePullRequestBaseBranches, "pull request base branch %q is not one of %q", pr.GetBase().GetRef(), allowed))
+	}
+	return nil
+}
+
+// pullRequestState returns whether the pull request is open, merged or
+// closed without being merged.
+func pullRequestState(pr *github.PullRequest) string {
+	switch {
+	case pr.GetState() == "open":
+		return pullRequestStateOpen
+	case pr.GetMerged() || pr.MergedAt != nil:
+		return pullRequestStateMerged
+	default:
+		return pullRequestStateClosed
+	}
+}
+
+// getPullRequestAccessToken gets an access token with contents and pull
+// request read permission to the repo which contains the pull request.
+func (v *Validator) getPullRequestAccessToken(ctx context.Context, repoName string) (string, error) {
+	tr := &githubauth.TokenRequest{
+		Repositories: []string{repoName},
+		Permissions: map[string]string{
+			"contents":      "read",
+			"pull_requests": "read",
+		},
+	}
+
+	resp, err := v.githubInstallation.AccessToken(ctx, tr)
+	if err != nil {
+		return "", fmt.Errorf("failed to get access token: %w", err)
+	}
+	return resp, nil
+}
+
+// parsePullRequestInfoFromURL parses pluginGitHubPullRequest from pull request
+// URL.
+func parsePullRequestInfoFromURL(pullRequestURL string) (*pluginGitHubPullRequest, error) {
+	if match, _ := regexp.MatchString(pullRequestURLPatternRegExp, pullRequestURL); !match {
+		return nil, fmt.Errorf("invalid pull request url, %s", referenceHint(pullRequestURL, referenceKindPullRequest))
+	}
+	u, err := url.Parse(pullRequestURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse provided pull request url: %w", err)
+	}
+
+	arr := strings.Split(u.Path, "/")
+	// len(arr) is not checked here as regexp.MatchString already covers this.
+	pullNumber, err := strconv.Atoi(arr[4])
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert pullNumber %s to int: %w", arr[4], err)
+	}
+
+	return &pluginGitHubPullRequest{
+		Owner:      arr[1],
+		RepoName:   arr[2],
+		PullNumber: pullNumber,
+	}, nil
+}
+
+// parseCommitInfoFromURL parses the repo and the commit ref from a commit URL,
+// or a compare URL in the "base...head" or "base..head" format, in which case
+// the head is returned.
+func parseCommitInfoFromURL(commitURL string) (*pluginGitHubPullRequest, string, error) {
+	u, err := url.Parse(commitURL)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse provided commit url: %w", err)
+	}
+	base := (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}).String()
+
+	// The head of compare URLs may be a branch containing slashes.
+	arr := strings.SplitN(u.Path, "/", 5)
+	switch {
+	case regexp.MustCompile(commitURLPatternRegExp).MatchString(base):
+		return &pluginGitHubPullRequest{Owner: arr[1], RepoName: arr[2]}, arr[4], nil
+	case regexp.MustCompile(compareURLPatternRegExp).MatchString(base):
+		_, head, ok := strings.Cut(arr[4], "...")
+		if !ok {
+			_, head, _ = strings.Cut(arr[4], "..")
+		}
+		if head == "" || strings.Contains(head, ":") {
+			return nil, "", fmt.Errorf("compare url head %q must be a ref in the same repository", head)
+		}
+		return &pluginGitHubPullRequest{Owner: arr[1], RepoName: arr[2]}, head, nil
+	default:
+		return nil, "", fmt.Errorf("invalid commit url, %s", referenceHint(commitURL, referenceKindCommit))
+	}
+}
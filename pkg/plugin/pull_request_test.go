@@ -0,0 +1,335 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v55/github"
+
+	"github.com/abcxyz/pkg/testutil"
+)
+
+const testCommitSHA = "abc1234def5678abc1234def5678abc1234def56"
+
+// testHandlePullRequests returns a fake http func that serves pull request 7
+// of the test repo, the commit testCommitSHA, also as the head of the "fix"
+// branch, and the given pull requests associated with the commit.
+func testHandlePullRequests(tb testing.TB, pullRequest, commitPullRequests string) func(w http.ResponseWriter, r *http.Request) {
+	tb.Helper()
+	repoPath := fmt.Sprintf("%s/%s/%s", issueRESTAPIPathPrefix, testIssueOwner, testIssueRepoName)
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case repoPath + "/pulls/7":
+			fmt.Fprint(w, pullRequest)
+		case repoPath + "/pulls/404", repoPath + "/commits/0000000":
+			http.Error(w, "not found", http.StatusNotFound)
+		case repoPath + "/commits/" + testCommitSHA, repoPath + "/commits/fix":
+			fmt.Fprint(w, testCommitSHA)
+		case repoPath + "/commits/" + testCommitSHA + "/pulls":
+			fmt.Fprint(w, commitPullRequests)
+		default:
+			http.Error(w, "injected server error", http.StatusInternalServerError)
+		}
+	}
+}
+
+func TestMatchPullRequest(t *testing.T) {
+	t.Parallel()
+
+	recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	old := time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339)
+
+	const pullRequestURL = "https://github.com/test-owner/test-repo/pull/7"
+
+	wantInfo := func(number int, state string) *pluginGitHubPullRequest {
+		return &pluginGitHubPullRequest{
+			Owner:      testIssueOwner,
+			RepoName:   testIssueRepoName,
+			PullNumber: number,
+			State:      state,
+		}
+	}
+
+	cases := []struct {
+		name           string
+		pullRequestURL string
+		policy         *Policy
+		pullRequest    string
+		want           *pluginGitHubPullRequest
+		wantErr        string
+		wantReason     Reason
+	}{
+		{
+			name:           "open",
+			pullRequestURL: pullRequestURL,
+			policy:         &Policy{},
+			pullRequest:    `{"number": 7, "state": "open", "base": {"ref": "main"}}`,
+			want:           wantInfo(7, "open"),
+		},
+		{
+			name:           "merged",
+			pullRequestURL: pullRequestURL,
+			policy:         &Policy{},
+			pullRequest:    fmt.Sprintf(`{"number": 7, "state": "closed", "merged": true, "merged_at": %q}`, recent),
+			want:           wantInfo(7, "merged"),
+			wantErr:        "pull request is merged",
+			wantReason:     ReasonPullRequestClosed,
+		},
+		{
+			name:           "merged_within_window",
+			pullRequestURL: pullRequestURL,
+			policy:         &Policy{PullRequestMergedWindow: 24 * time.Hour},
+			pullRequest:    fmt.Sprintf(`{"number": 7, "state": "closed", "merged": true, "merged_at": %q}`, recent),
+			want:           wantInfo(7, "merged"),
+		},
+		{
+			name:           "merged_outside_window",
+			pullRequestURL: pullRequestURL,
+			policy:         &Policy{PullRequestMergedWindow: 24 * time.Hour},
+			pullRequest:    fmt.Sprintf(`{"number": 7, "state": "closed", "merged": true, "merged_at": %q}`, old),
+			want:           wantInfo(7, "merged"),
+			wantErr:        "pull request was merged more than 24h0m0s ago",
+			wantReason:     ReasonPullRequestClosed,
+		},
+		{
+			name:           "closed",
+			pullRequestURL: pullRequestURL,
+			policy:         &Policy{PullRequestMergedWindow: 24 * time.Hour},
+			pullRequest:    `{"number": 7, "state": "closed"}`,
+			want:           wantInfo(7, "closed"),
+			wantErr:        "pull request is closed",
+			wantReason:     ReasonPullRequestClosed,
+		},
+		{
+			name:           "base_branch_not_allowed",
+			pullRequestURL: pullRequestURL,
+			policy:         &Policy{PullRequestBaseBranches: []string{"release"}},
+			pullRequest:    `{"number": 7, "state": "open", "base": {"ref": "main"}}`,
+			want:           wantInfo(7, "open"),
+			wantErr:        `pull request base branch "main" is not one of ["release"]`,
+			wantReason:     ReasonBranchNotAllowed,
+		},
+		{
+			name:           "not_found",
+			pullRequestURL: "https://github.com/test-owner/test-repo/pull/404",
+			policy:         &Policy{},
+			want:           wantInfo(404, ""),
+			wantErr:        "pull request not found",
+			wantReason:     ReasonReferenceNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			installation := testGitHubInstallation(t, http.StatusCreated)
+			hc := newTestServer(t, testHandlePullRequests(t, tc.pullRequest, ""))
+
+			v := NewValidator(github.NewClient(hc), installation, tc.policy)
+			got, err := v.MatchPullRequest(t.Context(), tc.pullRequestURL)
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if got, want := testReason(err), tc.wantReason; got != want {
+				t.Errorf("reason of %v got %q, want %q", err, got, want)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("MatchPullRequest() unexpected diff (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMatchCommit(t *testing.T) {
+	t.Parallel()
+
+	old := time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339)
+	commitURL := "https://github.com/test-owner/test-repo/commit/" + testCommitSHA
+
+	cases := []struct {
+		name               string
+		commitURL          string
+		policy             *Policy
+		commitPullRequests string
+		want               *pluginGitHubPullRequest
+		wantErr            string
+		wantInvalid        bool
+	}{
+		{
+			name:               "open_pull_request",
+			commitURL:          commitURL,
+			policy:             &Policy{},
+			commitPullRequests: fmt.Sprintf(`[{"number": 6, "state": "closed", "merged_at": %q}, {"number": 7, "state": "open"}]`, old),
+			want: &pluginGitHubPullRequest{
+				Owner:      testIssueOwner,
+				RepoName:   testIssueRepoName,
+				PullNumber: 7,
+				CommitSHA:  testCommitSHA,
+				State:      "open",
+			},
+		},
+		{
+			name:               "compare_head",
+			commitURL:          "https://github.com/test-owner/test-repo/compare/main...fix",
+			policy:             &Policy{},
+			commitPullRequests: `[{"number": 7, "state": "open"}]`,
+			want: &pluginGitHubPullRequest{
+				Owner:      testIssueOwner,
+				RepoName:   testIssueRepoName,
+				PullNumber: 7,
+				CommitSHA:  testCommitSHA,
+				State:      "open",
+			},
+		},
+		{
+			name:               "no_pull_request",
+			commitURL:          commitURL,
+			policy:             &Policy{},
+			commitPullRequests: `[]`,
+			wantErr:            "is not associated with any pull request",
+			wantInvalid:        true,
+		},
+		{
+			name:               "no_pull_request_satisfying_policy",
+			commitURL:          commitURL,
+			policy:             &Policy{PullRequestMergedWindow: 24 * time.Hour},
+			commitPullRequests: fmt.Sprintf(`[{"number": 6, "state": "closed", "merged_at": %q}]`, old),
+			wantErr:            "pull request #6: invalid justification: pull request was merged more than 24h0m0s ago",
+			wantInvalid:        true,
+		},
+		{
+			name:        "commit_not_found",
+			commitURL:   "https://github.com/test-owner/test-repo/commit/0000000",
+			policy:      &Policy{},
+			wantErr:     "commit 0000000 not found",
+			wantInvalid: true,
+		},
+		{
+			name:        "cross_repo_compare",
+			commitURL:   "https://github.com/test-owner/test-repo/compare/main...fork:fix",
+			policy:      &Policy{},
+			wantErr:     "must be a ref in the same repository",
+			wantInvalid: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			installation := testGitHubInstallation(t, http.StatusCreated)
+			hc := newTestServer(t, testHandlePullRequests(t, "", tc.commitPullRequests))
+
+			v := NewValidator(github.NewClient(hc), installation, tc.policy)
+			got, err := v.MatchCommit(t.Context(), tc.commitURL)
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if got, want := errors.Is(err, errInvalidJustification), tc.wantInvalid; got != want {
+				t.Errorf("errors.Is(%v, errInvalidJustification) = %t, want %t", err, got, want)
+			}
+			if tc.wantErr == "" || tc.want != nil {
+				if diff := cmp.Diff(tc.want, got); diff != "" {
+					t.Errorf("MatchCommit() unexpected diff (-want,+got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchCommit_PolicyWarnings(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name               string
+		commitPullRequests string
+		wantPullNumber     int
+		wantWarnings       []string
+	}{
+		{
+			// Pull request 6 is rejected for closing no issue, so its base
+			// branch warning is not reported.
+			name: "rejected_candidate_warnings_dropped",
+			commitPullRequests: `[
+				{"number": 6, "state": "open", "base": {"ref": "release"}},
+				{"number": 7, "state": "open", "base": {"ref": "main"}}
+			]`,
+			wantPullNumber: 7,
+		},
+		{
+			name: "accepted_candidate_warnings_kept",
+			commitPullRequests: `[
+				{"number": 6, "state": "open", "base": {"ref": "release"}},
+				{"number": 7, "state": "open", "base": {"ref": "dev"}}
+			]`,
+			wantPullNumber: 7,
+			wantWarnings: []string{
+				`invalid justification: pull request base branch "dev" is not one of ["main"]`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			installation := testGitHubInstallation(t, http.StatusCreated)
+			pullRequestHandler := testHandlePullRequests(t, "", tc.commitPullRequests)
+			graphQLHandler := testHandleGraphQL(t, func(vars map[string]any) string {
+				if vars["number"] == float64(7) {
+					return `{"data": {"repository": {"pullRequest": {"closingIssuesReferences": {"nodes": [
+						{"url": "https://github.com/test-owner/test-repo/issues/1"}
+					]}}}}}`
+				}
+				return `{"data": {"repository": {"pullRequest": {"closingIssuesReferences": {"nodes": []}}}}}`
+			})
+			hc := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/graphql" {
+					graphQLHandler(w, r)
+					return
+				}
+				pullRequestHandler(w, r)
+			})
+
+			v := NewValidator(github.NewClient(hc), installation, &Policy{
+				PullRequestBaseBranches:       []string{"main"},
+				PullRequestRequireLinkedIssue: true,
+				Enforcement:                   []string{"BRANCH_NOT_ALLOWED=warn"},
+			})
+			ctx, warnings := withPolicyWarnings(t.Context())
+			got, err := v.MatchCommit(ctx, "https://github.com/test-owner/test-repo/commit/"+testCommitSHA)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := got.PullNumber, tc.wantPullNumber; got != want {
+				t.Errorf("PullNumber got %d, want %d", got, want)
+			}
+			var gotWarnings []string
+			for _, w := range warnings.list() {
+				gotWarnings = append(gotWarnings, w.Error())
+			}
+			if diff := cmp.Diff(tc.wantWarnings, gotWarnings); diff != "" {
+				t.Errorf("warnings unexpected diff (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
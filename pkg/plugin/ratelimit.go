@@ -0,0 +1,79 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"sync"
+	"time"
+
+	"github.com/abcxyz/pkg/cache"
+)
+
+// rateLimiter is a token bucket rate limiter per key.
+type rateLimiter struct {
+	rate     int
+	interval time.Duration
+	// now returns the current time, it is overridden in tests.
+	now func() time.Time
+
+	mu sync.Mutex
+	// buckets expire after an interval without requests, when they would be
+	// full again anyway.
+	buckets *cache.Cache[*tokenBucket]
+}
+
+// tokenBucket is the state of a key.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter creates a rate limiter allowing rate requests per interval
+// per key, in bursts of up to rate requests.
+func newRateLimiter(rate int, interval time.Duration) *rateLimiter {
+	return &rateLimiter{
+		rate:     rate,
+		interval: interval,
+		now:      time.Now,
+		buckets:  cache.New[*tokenBucket](interval),
+	}
+}
+
+// Allow reports whether a request for the key is allowed, and consumes a token
+// if so.
+func (l *rateLimiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets.Lookup(key)
+	if !ok {
+		b = &tokenBucket{tokens: float64(l.rate), last: now}
+	}
+
+	// Refill the tokens accrued since the last request.
+	b.tokens += now.Sub(b.last).Seconds() / l.interval.Seconds() * float64(l.rate)
+	if capacity := float64(l.rate); b.tokens > capacity {
+		b.tokens = capacity
+	}
+	b.last = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	l.buckets.Set(key, b)
+	return allowed
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	l := newRateLimiter(2, time.Minute)
+	l.now = func() time.Time { return now }
+
+	steps := []struct {
+		advance time.Duration
+		key     string
+		want    bool
+	}{
+		{key: "a", want: true},
+		{key: "a", want: true},
+		{key: "a", want: false},
+		{key: "b", want: true},
+		// A token is refilled every 30 seconds.
+		{advance: 20 * time.Second, key: "a", want: false},
+		{advance: 10 * time.Second, key: "a", want: true},
+		{key: "a", want: false},
+		// The bucket doesn't fill beyond the rate.
+		{advance: time.Hour, key: "a", want: true},
+		{key: "a", want: true},
+		{key: "a", want: false},
+	}
+
+	for i, s := range steps {
+		now = now.Add(s.advance)
+		if got := l.Allow(s.key); got != s.want {
+			t.Errorf("step %d: Allow(%q) = %t, want %t", i, s.key, got, s.want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"net/url"
+	"strings"
+)
+
+// referenceKind is the kind of GitHub object a justification refers to.
+type referenceKind int
+
+const (
+	referenceKindIssue referenceKind = iota
+	referenceKindDiscussion
+	referenceKindProjectItem
+	referenceKindWorkflowRun
+	referenceKindDeployment
+	referenceKindSecurity
+	referenceKindPullRequest
+	referenceKindCommit
+)
+
+// referenceKindFromURL determines the kind of GitHub object the URL refers to
+// from its path. URLs that are not recognized are treated as issues, so the
+// issue URL parser reports the error.
+func referenceKindFromURL(s string) referenceKind {
+	u, err := url.Parse(s)
+	if err != nil {
+		return referenceKindIssue
+	}
+
+	// The path is /<owner>/<repo>/<kind>/...
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 3 {
+		return referenceKindIssue
+	}
+
+	switch parts[2] {
+	case "discussions":
+		return referenceKindDiscussion
+	case "deployments":
+		return referenceKindDeployment
+	case "security":
+		return referenceKindSecurity
+	case "pull":
+		return referenceKindPullRequest
+	case "commit", "compare":
+		return referenceKindCommit
+	case "projects":
+		// Projects are owned by organizations or users, not repositories.
+		if parts[0] == "orgs" || parts[0] == "users" {
+			return referenceKindProjectItem
+		}
+		return referenceKindIssue
+	case "actions":
+		if len(parts) > 3 && parts[3] == "runs" {
+			if isDeploymentReference(u) {
+				return referenceKindDeployment
+			}
+			return referenceKindWorkflowRun
+		}
+		return referenceKindIssue
+	default:
+		return referenceKindIssue
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"testing"
+)
+
+func TestReferenceKindFromURL(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		url  string
+		want referenceKind
+	}{
+		{
+			name: "issue",
+			url:  "https://github.com/owner/repo/issues/1",
+			want: referenceKindIssue,
+		},
+		{
+			name: "discussion",
+			url:  "https://github.com/owner/repo/discussions/1",
+			want: referenceKindDiscussion,
+		},
+		{
+			name: "org_project_item",
+			url:  "https://github.com/orgs/owner/projects/5/views/1?pane=issue&issue=owner%7Crepo%7C1",
+			want: referenceKindProjectItem,
+		},
+		{
+			name: "user_project",
+			url:  "https://github.com/users/owner/projects/5",
+			want: referenceKindProjectItem,
+		},
+		{
+			name: "workflow_run",
+			url:  "https://github.com/owner/repo/actions/runs/123/job/456",
+			want: referenceKindWorkflowRun,
+		},
+		{
+			name: "deployment",
+			url:  "https://github.com/owner/repo/deployments/123",
+			want: referenceKindDeployment,
+		},
+		{
+			name: "workflow_run_deployment",
+			url:  "https://github.com/owner/repo/actions/runs/123?environment=production",
+			want: referenceKindDeployment,
+		},
+		{
+			name: "security_advisory",
+			url:  "https://github.com/owner/repo/security/advisories/GHSA-abcd-efgh-ijkl",
+			want: referenceKindSecurity,
+		},
+		{
+			name: "pull_request",
+			url:  "https://github.com/owner/repo/pull/1",
+			want: referenceKindPullRequest,
+		},
+		{
+			name: "commit",
+			url:  "https://github.com/owner/repo/commit/abc123",
+			want: referenceKindCommit,
+		},
+		{
+			name: "compare",
+			url:  "https://github.com/owner/repo/compare/main...fix",
+			want: referenceKindCommit,
+		},
+		{
+			name: "workflows",
+			url:  "https://github.com/owner/repo/actions/workflows/deploy.yml",
+			want: referenceKindIssue,
+		},
+		{
+			name: "repo",
+			url:  "https://github.com/owner/repo",
+			want: referenceKindIssue,
+		},
+		{
+			name: "unparsable",
+			url:  "://",
+			want: referenceKindIssue,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := referenceKindFromURL(tc.url); got != tc.want {
+				t.Errorf("referenceKindFromURL(%q) = %v, want %v", tc.url, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	jvspb "github.com/abcxyz/jvs/apis/v0"
+	"github.com/abcxyz/pkg/logging"
+	"github.com/abcxyz/pkg/workerpool"
+)
+
+const (
+	// respAnnotationKeyReferencePrefix prefixes the annotations of each
+	// reference of a justification with several references, followed by its
+	// index, e.g. "github_ref_0_url" or "github_ref_0_issue_number".
+	respAnnotationKeyReferencePrefix = "github_ref_"
+
+	// respAnnotationKeyReferences are the comma separated valid references,
+	// summarizing a justification with several references.
+	respAnnotationKeyReferences          = "github_refs"
+	respAnnotationKeyReferenceMode       = "github_ref_mode"
+	respAnnotationKeyReferenceCount      = "github_ref_count"
+	respAnnotationKeyReferenceValidCount = "github_ref_valid_count"
+)
+
+// referenceResult is the result of validating a single reference.
+type referenceResult struct {
+	// annotation are the response annotations describing the reference.
+	annotation map[string]string
+	// issue is the validated issue to write back to, nil for other kinds of
+	// references.
+	issue *pluginGitHubIssue
+	// warnings are the policy violations of the reference which were not
+	// enforced.
+	warnings []error
+	// degraded is whether the reference was accepted in degraded mode, without
+	// GitHub.
+	degraded bool
+}
+
+// parseReferences splits the justification value into its comma or whitespace
+// separated references, dropping duplicates.
+func parseReferences(value string) []string {
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+
+	refs := make([]string, 0, len(fields))
+	seen := make(map[string]struct{}, len(fields))
+	for _, f := range fields {
+		if _, ok := seen[f]; ok {
+			continue
+		}
+		seen[f] = struct{}{}
+		refs = append(refs, f)
+	}
+	return refs
+}
+
+// validateReferences validates the references of a justification with several
+// references concurrently. With the "all" reference mode every reference must
+// be valid, with "any" at least one.
+func (g *GitHubPlugin) validateReferences(ctx context.Context, category *pluginCategory, j *jvspb.Justification, refs []string) (*jvspb.ValidateJustificationResponse, error) {
+	if category.maxReferences > 0 && len(refs) > category.maxReferences {
+		return generateInvalidErrResq(ReasonTooManyReferences, fmt.Sprintf("%s: justification has %d references, at most %d are allowed",
+			errInvalidJustification, len(refs), category.maxReferences)), nil
+	}
+
+	pool := workerpool.New[*referenceResult](&workerpool.Config{
+		Concurrency: int64(len(refs)),
+	})
+	for _, ref := range refs {
+		if err := pool.Do(ctx, func() (*referenceResult, error) {
+			return g.matchReferenceWithFallback(ctx, category, j, ref)
+		}); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to validate references: %s", err)
+		}
+	}
+	// Errors are inspected per reference below.
+	results, _ := pool.Done(ctx)
+	if len(results) != len(refs) {
+		return nil, status.Errorf(codes.Internal, "failed to validate references: %s", ctx.Err())
+	}
+
+	logger := logging.FromContext(ctx)
+	annotation := make(map[string]string, len(refs)*8)
+	var invalid []string
+	var reasons []string
+	var valid []string
+	var warnings []string
+	var internalErr error
+	for i, r := range results {
+		prefix := respAnnotationKeyReferencePrefix + strconv.Itoa(i) + "_"
+		annotation[prefix+"url"] = refs[i]
+
+		if r.Error != nil {
+			if !errors.Is(r.Error, errInvalidJustification) {
+				logger.ErrorContext(ctx, "failed to validate reference",
+					"reference", refs[i],
+					"error", r.Error)
+				internalErr = errors.Join(internalErr, fmt.Errorf("reference %d %s: %w", i, refs[i], r.Error))
+				continue
+			}
+			reason := reasonOf(r.Error)
+			invalid = append(invalid, formatReason(reason, fmt.Sprintf("reference %d %s: %s", i, refs[i], r.Error)))
+			if !slices.Contains(reasons, string(reason)) {
+				reasons = append(reasons, string(reason))
+			}
+			annotation[prefix+"error"] = r.Error.Error()
+			annotation[prefix+"reason"] = string(reason)
+			continue
+		}
+
+		valid = append(valid, refs[i])
+		if r.Value.degraded {
+			annotation[respAnnotationKeyValidationDegraded] = "true"
+		}
+		for _, w := range r.Value.warnings {
+			warnings = append(warnings, formatReason(reasonOf(w), fmt.Sprintf("reference %d %s: %s", i, refs[i], w)))
+		}
+		for k, v := range r.Value.annotation {
+			annotation[prefix+strings.TrimPrefix(k, "github_")] = v
+		}
+	}
+
+	switch category.referenceMode {
+	case referenceModeAny:
+		if len(valid) == 0 {
+			if internalErr != nil {
+				return nil, validationStatusError(internalErr)
+			}
+			return invalidReferencesResq(invalid, reasons), nil
+		}
+	default:
+		if len(invalid) > 0 {
+			return invalidReferencesResq(invalid, reasons), nil
+		}
+		if internalErr != nil {
+			return nil, validationStatusError(internalErr)
+		}
+	}
+
+	for _, r := range results {
+		if r.Error == nil {
+			g.writeBack(ctx, j, r.Value)
+		}
+	}
+
+	mode := category.referenceMode
+	if mode == "" {
+		mode = referenceModeAll
+	}
+	annotation[respAnnotationKeyReferences] = strings.Join(valid, ",")
+	annotation[respAnnotationKeyReferenceMode] = mode
+	annotation[respAnnotationKeyReferenceCount] = strconv.Itoa(len(refs))
+	annotation[respAnnotationKeyReferenceValidCount] = strconv.Itoa(len(valid))
+	return &jvspb.ValidateJustificationResponse{
+		Valid:      true,
+		Warning:    warnings,
+		Annotation: annotation,
+	}, nil
+}
+
+// invalidReferencesResq generates a ValidateJustificationResponse indicating
+// the justification is invalid because of its invalid references, with the
+// error messages and reasons of each.
+func invalidReferencesResq(errs, reasons []string) *jvspb.ValidateJustificationResponse {
+	return &jvspb.ValidateJustificationResponse{
+		Valid:      false,
+		Error:      errs,
+		Annotation: map[string]string{respAnnotationKeyReason: strings.Join(reasons, ",")},
+	}
+}
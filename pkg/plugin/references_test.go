@@ -0,0 +1,346 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	jvspb "github.com/abcxyz/jvs/apis/v0"
+	"github.com/abcxyz/pkg/testutil"
+)
+
+const testGitHubPullRequestURL = "https://github.com/test-owner/test-repo/pull/2"
+
+// testReferencesMatcher matches issues and pull requests by URL, failing with
+// the error set for the URL if any.
+type testReferencesMatcher struct {
+	testReferenceMatcher
+	errs map[string]error
+}
+
+func (t *testReferencesMatcher) MatchIssue(ctx context.Context, issueURL string) (*pluginGitHubIssue, error) {
+	if err := t.errs[issueURL]; err != nil {
+		return nil, err
+	}
+	return parseIssueInfoFromURL(issueURL)
+}
+
+func (t *testReferencesMatcher) MatchPullRequest(ctx context.Context, pullRequestURL string) (*pluginGitHubPullRequest, error) {
+	if err := t.errs[pullRequestURL]; err != nil {
+		return nil, err
+	}
+	return &pluginGitHubPullRequest{Owner: "test-owner", RepoName: "test-repo", PullNumber: 2, State: "open"}, nil
+}
+
+func TestParseReferences(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{
+			name: "empty",
+			want: []string{},
+		},
+		{
+			name:  "single",
+			value: " " + testGitHubIssueURL + "\n",
+			want:  []string{testGitHubIssueURL},
+		},
+		{
+			name:  "comma_and_whitespace_separated",
+			value: testGitHubIssueURL + ", " + testGitHubPullRequestURL + "\t" + testGitHubDiscussionURL,
+			want:  []string{testGitHubIssueURL, testGitHubPullRequestURL, testGitHubDiscussionURL},
+		},
+		{
+			name:  "duplicates",
+			value: testGitHubIssueURL + "," + testGitHubPullRequestURL + "," + testGitHubIssueURL,
+			want:  []string{testGitHubIssueURL, testGitHubPullRequestURL},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tc.want, parseReferences(tc.value)); diff != "" {
+				t.Errorf("parseReferences() unexpected diff (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_MultipleReferences(t *testing.T) {
+	t.Parallel()
+
+	invalidIssue := invalidf(ReasonIssueClosed, "issue is closed")
+	value := testGitHubIssueURL + ", " + testGitHubPullRequestURL
+
+	repositoryAnnotation := func(i int) map[string]string {
+		prefix := fmt.Sprintf("github_ref_%d_", i)
+		return map[string]string{
+			prefix + "repository":            "test-owner/test-repo",
+			prefix + "repository_visibility": "private",
+			prefix + "repository_archived":   "false",
+			prefix + "repository_fork":       "false",
+		}
+	}
+	withAnnotation := func(annotations ...map[string]string) map[string]string {
+		got := make(map[string]string)
+		for _, m := range annotations {
+			maps.Copy(got, m)
+		}
+		return got
+	}
+
+	cases := []struct {
+		name          string
+		mode          string
+		maxReferences int
+		errs          map[string]error
+		wantResq      *jvspb.ValidateJustificationResponse
+		wantErr       string
+	}{
+		{
+			name: "all_success",
+			wantResq: &jvspb.ValidateJustificationResponse{
+				Valid: true,
+				Annotation: withAnnotation(repositoryAnnotation(0), repositoryAnnotation(1), map[string]string{
+					"github_ref_0_url":                 testGitHubIssueURL,
+					"github_ref_0_issue_url":           testGitHubIssueURL,
+					"github_ref_0_issue_owner":         "test-owner",
+					"github_ref_0_issue_repo":          "test-repo",
+					"github_ref_0_issue_number":        "1",
+					"github_ref_0_issue_snapshot_hash": "",
+					"github_ref_1_url":                 testGitHubPullRequestURL,
+					"github_ref_1_pull_request_url":    testGitHubPullRequestURL,
+					"github_ref_1_pull_request_owner":  "test-owner",
+					"github_ref_1_pull_request_repo":   "test-repo",
+					"github_ref_1_pull_request_number": "2",
+					"github_ref_1_pull_request_state":  "open",
+					"github_refs":                      testGitHubIssueURL + "," + testGitHubPullRequestURL,
+					"github_ref_mode":                  referenceModeAll,
+					"github_ref_count":                 "2",
+					"github_ref_valid_count":           "2",
+				}),
+			},
+		},
+		{
+			name: "all_invalid",
+			errs: map[string]error{testGitHubIssueURL: invalidIssue},
+			wantResq: &jvspb.ValidateJustificationResponse{
+				Valid:      false,
+				Error:      []string{"[ISSUE_CLOSED] reference 0 " + testGitHubIssueURL + ": invalid justification: issue is closed"},
+				Annotation: map[string]string{respAnnotationKeyReason: "ISSUE_CLOSED"},
+			},
+		},
+		{
+			name:    "all_internal_error",
+			errs:    map[string]error{testGitHubPullRequestURL: fmt.Errorf("injected error")},
+			wantErr: "injected error",
+		},
+		{
+			name: "any_success",
+			mode: referenceModeAny,
+			errs: map[string]error{testGitHubIssueURL: invalidIssue},
+			wantResq: &jvspb.ValidateJustificationResponse{
+				Valid: true,
+				Annotation: withAnnotation(repositoryAnnotation(1), map[string]string{
+					"github_ref_0_url":                 testGitHubIssueURL,
+					"github_ref_0_error":               "invalid justification: issue is closed",
+					"github_ref_0_reason":              "ISSUE_CLOSED",
+					"github_ref_1_url":                 testGitHubPullRequestURL,
+					"github_ref_1_pull_request_url":    testGitHubPullRequestURL,
+					"github_ref_1_pull_request_owner":  "test-owner",
+					"github_ref_1_pull_request_repo":   "test-repo",
+					"github_ref_1_pull_request_number": "2",
+					"github_ref_1_pull_request_state":  "open",
+					"github_refs":                      testGitHubPullRequestURL,
+					"github_ref_mode":                  referenceModeAny,
+					"github_ref_count":                 "2",
+					"github_ref_valid_count":           "1",
+				}),
+			},
+		},
+		{
+			name: "any_invalid",
+			mode: referenceModeAny,
+			errs: map[string]error{
+				testGitHubIssueURL:       invalidIssue,
+				testGitHubPullRequestURL: invalidf(ReasonPullRequestClosed, "pull request is closed"),
+			},
+			wantResq: &jvspb.ValidateJustificationResponse{
+				Valid: false,
+				Error: []string{
+					"[ISSUE_CLOSED] reference 0 " + testGitHubIssueURL + ": invalid justification: issue is closed",
+					"[PULL_REQUEST_CLOSED] reference 1 " + testGitHubPullRequestURL + ": invalid justification: pull request is closed",
+				},
+				Annotation: map[string]string{respAnnotationKeyReason: "ISSUE_CLOSED,PULL_REQUEST_CLOSED"},
+			},
+		},
+		{
+			name:          "too_many_references",
+			maxReferences: 1,
+			wantResq: &jvspb.ValidateJustificationResponse{
+				Valid:      false,
+				Error:      []string{"[TOO_MANY_REFERENCES] invalid justification: justification has 2 references, at most 1 are allowed"},
+				Annotation: map[string]string{respAnnotationKeyReason: "TOO_MANY_REFERENCES"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &GitHubPlugin{
+				categories: map[string]*pluginCategory{
+					githubCategory: {
+						validator:        &testReferencesMatcher{errs: tc.errs},
+						referenceMode:    tc.mode,
+						maxReferences:    tc.maxReferences,
+						repositoryPolicy: true,
+					},
+				},
+			}
+			gotResq, gotErr := p.Validate(t.Context(), &jvspb.ValidateJustificationRequest{
+				Justification: &jvspb.Justification{
+					Category: githubCategory,
+					Value:    value,
+				},
+			})
+			if diff := testutil.DiffErrString(gotErr, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tc.wantResq, gotResq, cmpopts.IgnoreUnexported(jvspb.ValidateJustificationResponse{})); diff != "" {
+				t.Errorf("Failed validation (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_SingleParsedReference(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		value string
+	}{
+		{
+			name:  "trailing_comma",
+			value: testGitHubIssueURL + ",",
+		},
+		{
+			name:  "duplicate",
+			value: testGitHubIssueURL + " " + testGitHubIssueURL,
+		},
+		{
+			name:  "surrounding_space",
+			value: " " + testGitHubIssueURL + "\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &GitHubPlugin{
+				categories: map[string]*pluginCategory{
+					githubCategory: {validator: &testReferencesMatcher{}},
+				},
+			}
+			got, err := p.Validate(t.Context(), &jvspb.ValidateJustificationRequest{
+				Justification: &jvspb.Justification{
+					Category: githubCategory,
+					Value:    tc.value,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.GetValid() {
+				t.Errorf("Validate(%q) got invalid, errors: %q", tc.value, got.GetError())
+			}
+			if got, want := got.GetAnnotation()[respAnnotationKeyIssueURL], testGitHubIssueURL; got != want {
+				t.Errorf("issue url annotation got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestValidate_MultipleReferencesWriteBack(t *testing.T) {
+	t.Parallel()
+
+	otherIssueURL := "https://github.com/test-owner/test-repo/issues/4"
+
+	cases := []struct {
+		name        string
+		mode        string
+		wantEntries int
+	}{
+		{
+			name: "all_no_write_back_when_invalid",
+		},
+		{
+			name:        "any_writes_back_valid_issues",
+			mode:        referenceModeAny,
+			wantEntries: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			writer := &testIssueWriter{}
+			p := &GitHubPlugin{
+				categories: map[string]*pluginCategory{
+					githubCategory: {
+						validator: &testReferencesMatcher{errs: map[string]error{
+							otherIssueURL: invalidf(ReasonIssueClosed, "issue is closed"),
+						}},
+						referenceMode: tc.mode,
+					},
+				},
+				writer: writer,
+				now:    time.Now,
+			}
+			if _, err := p.Validate(t.Context(), &jvspb.ValidateJustificationRequest{
+				Justification: &jvspb.Justification{
+					Category: githubCategory,
+					Value:    testGitHubIssueURL + " " + otherIssueURL,
+				},
+			}); err != nil {
+				t.Fatal(err)
+			}
+			if err := p.Close(t.Context()); err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := len(writer.entries), tc.wantEntries; got != want {
+				t.Errorf("write-back entries got %d, want %d", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abcxyz/pkg/githubauth"
+)
+
+// defaultRepositoryCacheTTL is how long repository metadata is cached by
+// default.
+const defaultRepositoryCacheTTL = 5 * time.Minute
+
+// repositoryVisibilities are the allowed values of
+// Policy.RepositoryVisibilities.
+var repositoryVisibilities = []string{"public", "private", "internal"}
+
+// pluginGitHubRepository contains the metadata of the repository a
+// justification refers to.
+type pluginGitHubRepository struct {
+	Owner      string
+	RepoName   string
+	Visibility string
+	Archived   bool
+	Fork       bool
+	Topics     []string
+
+	// Properties are the values of the repository custom properties by
+	// property name. Multi select properties have multiple values.
+	Properties map[string][]string
+}
+
+// MatchRepository fetches the repository metadata, from the cache if possible,
+// and validates the repository against the repository policy.
+func (v *Validator) MatchRepository(ctx context.Context, owner, repoName string) (*pluginGitHubRepository, error) {
+	info, err := v.lookupRepository(ctx, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	if allowed := v.policy.RepositoryVisibilities; len(allowed) > 0 && !slices.Contains(allowed, info.Visibility) {
+		if err := v.enforce(ctx, violationf(ruleRepositoryVisibilities, "repository %s/%s is %s, expected one of %q",
+			info.Owner, info.RepoName, info.Visibility, allowed)); err != nil {
+			return info, err
+		}
+	}
+	if v.policy.RepositoryDenyArchived && info.Archived {
+		if err := v.enforce(ctx, violationf(ruleRepositoryDenyArchived, "repository %s/%s is archived", info.Owner, info.RepoName)); err != nil {
+			return info, err
+		}
+	}
+	if v.policy.RepositoryDenyForks && info.Fork {
+		if err := v.enforce(ctx, violationf(ruleRepositoryDenyForks, "repository %s/%s is a fork", info.Owner, info.RepoName)); err != nil {
+			return info, err
+		}
+	}
+	var missing []string
+	for _, topic := range v.policy.RepositoryTopics {
+		// Topics are always lowercase.
+		if !slices.Contains(info.Topics, strings.ToLower(topic)) {
+			missing = append(missing, topic)
+		}
+	}
+	if len(missing) > 0 {
+		if err := v.enforce(ctx, violationf(ruleRepositoryTopics, "repository %s/%s is missing required topics %q",
+			info.Owner, info.RepoName, missing)); err != nil {
+			return info, err
+		}
+	}
+	for _, property := range v.policy.RepositoryProperties {
+		name, value, err := parsePropertyCondition(property)
+		if err != nil {
+			return info, fmt.Errorf("invalid repository property policy: %w", err)
+		}
+		if !info.HasProperty(name, value) {
+			if err := v.enforce(ctx, violationf(ruleRepositoryProperties, "repository %s/%s custom property %s is %q, expected %q",
+				info.Owner, info.RepoName, name, info.Properties[name], value)); err != nil {
+				return info, err
+			}
+		}
+	}
+	return info, nil
+}
+
+// hasRepositoryPolicy reports whether the policy depends on the repositories
+// of references, so they must be looked up.
+func (p *Policy) hasRepositoryPolicy() bool {
+	return len(p.RepositoryVisibilities) > 0 || p.RepositoryDenyArchived || p.RepositoryDenyForks ||
+		len(p.RepositoryTopics) > 0 || len(p.RepositoryProperties) > 0 || len(p.RepositoryPropertyLabels) > 0
+}
+
+// lookupRepository returns the repository metadata from the cache, or fetches
+// and caches it.
+func (v *Validator) lookupRepository(ctx context.Context, owner, repoName string) (*pluginGitHubRepository, error) {
+	// Owners and repository names are case insensitive.
+	key := strings.ToLower(owner + "/" + repoName)
+	if v.repositories != nil {
+		if info, ok := v.repositories.Lookup(key); ok {
+			return info, nil
+		}
+	}
+
+	info, err := v.getRepository(ctx, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+	if v.repositories != nil {
+		v.repositories.Set(key, info)
+	}
+	return info, nil
+}
+
+// getRepository fetches the repository metadata.
+func (v *Validator) getRepository(ctx context.Context, owner, repoName string) (*pluginGitHubRepository, error) {
+	t, err := v.githubInstallation.AccessToken(ctx, &githubauth.TokenRequest{
+		Repositories: []string{repoName},
+		Permissions: map[string]string{
+			"metadata": "read",
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+	c := v.client.WithAuthToken(t)
+
+	repo, resp, err := c.Repositories.Get(ctx, owner, repoName)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, invalidf(ReasonReferenceNotFound, "repository %s/%s not found: %w", owner, repoName, err)
+		}
+		return nil, fmt.Errorf("failed to get repository info: %w", err)
+	}
+
+	properties, err := getCustomProperties(ctx, c, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pluginGitHubRepository{
+		// Use the canonical names rather than the ones in the URL.
+		Owner:      repo.GetOwner().GetLogin(),
+		RepoName:   repo.GetName(),
+		Visibility: repo.GetVisibility(),
+		Archived:   repo.GetArchived(),
+		Fork:       repo.GetFork(),
+		Topics:     repo.Topics,
+		Properties: properties,
+	}, nil
+}
+
+// repositoryFromURL returns the owner and name of the repository the reference
+// URL belongs to. ok is false when the URL doesn't refer to a repository, so
+// the reference matcher reports the error.
+func repositoryFromURL(s string) (owner, repoName string, ok bool) {
+	u, err := url.Parse(s)
+	if err != nil || u.Host != "github.com" {
+		return "", "", false
+	}
+
+	if referenceKindFromURL(s) == referenceKindProjectItem {
+		// Project item URLs refer to the issue repository in the "issue" query
+		// parameter as "owner|repo|number".
+		parts := strings.Split(u.Query().Get("issue"), "|")
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+			return "", "", false
+		}
+		return parts[0], parts[1], true
+	}
+
+	// The path is /<owner>/<repo>/...
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// repositoryAnnotations returns the response annotations describing the
+// repository.
+func repositoryAnnotations(info *pluginGitHubRepository) map[string]string {
+	annotation := map[string]string{
+		respAnnotationKeyRepository:           info.Owner + "/" + info.RepoName,
+		respAnnotationKeyRepositoryVisibility: info.Visibility,
+		respAnnotationKeyRepositoryArchived:   strconv.FormatBool(info.Archived),
+		respAnnotationKeyRepositoryFork:       strconv.FormatBool(info.Fork),
+	}
+	if len(info.Topics) > 0 {
+		annotation[respAnnotationKeyRepositoryTopics] = strings.Join(info.Topics, ",")
+	}
+	for name, values := range info.Properties {
+		annotation[respAnnotationKeyRepositoryPropertyPrefix+name] = strings.Join(values, ",")
+	}
+	return annotation
+}
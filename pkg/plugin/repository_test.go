@@ -0,0 +1,283 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v55/github"
+
+	"github.com/abcxyz/pkg/testutil"
+)
+
+func TestRepositoryFromURL(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		url       string
+		wantOwner string
+		wantRepo  string
+		wantOK    bool
+	}{
+		{
+			name:      "issue",
+			url:       testGitHubIssueURL,
+			wantOwner: "test-owner",
+			wantRepo:  "test-repo",
+			wantOK:    true,
+		},
+		{
+			name:      "workflow_run",
+			url:       testGitHubWorkflowRunURL,
+			wantOwner: "test-owner",
+			wantRepo:  "test-repo",
+			wantOK:    true,
+		},
+		{
+			name:      "project_item",
+			url:       testGitHubProjectItemURL,
+			wantOwner: "test-owner",
+			wantRepo:  "test-repo",
+			wantOK:    true,
+		},
+		{
+			name: "project_without_item",
+			url:  "https://github.com/orgs/test-owner/projects/5",
+		},
+		{
+			name: "owner_only",
+			url:  "https://github.com/test-owner",
+		},
+		{
+			name: "other_host",
+			url:  "https://example.com/test-owner/test-repo/issues/1",
+		},
+		{
+			name: "unparsable",
+			url:  "://",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			owner, repo, ok := repositoryFromURL(tc.url)
+			if owner != tc.wantOwner || repo != tc.wantRepo || ok != tc.wantOK {
+				t.Errorf("repositoryFromURL(%q) = (%q, %q, %t), want (%q, %q, %t)",
+					tc.url, owner, repo, ok, tc.wantOwner, tc.wantRepo, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestMatchRepository(t *testing.T) {
+	t.Parallel()
+
+	repository := func(visibility string, archived, fork bool) string {
+		return fmt.Sprintf(`{"name": %q, "owner": {"login": %q}, "visibility": %q, "archived": %t, "fork": %t, "topics": ["production-service", "go"]}`,
+			testIssueRepoName, testIssueOwner, visibility, archived, fork)
+	}
+
+	wantInfo := func(visibility string, archived, fork bool) *pluginGitHubRepository {
+		return &pluginGitHubRepository{
+			Owner:      testIssueOwner,
+			RepoName:   testIssueRepoName,
+			Visibility: visibility,
+			Archived:   archived,
+			Fork:       fork,
+			Topics:     []string{"production-service", "go"},
+			Properties: map[string][]string{
+				"tier":    {"critical"},
+				"regions": {"us", "eu"},
+			},
+		}
+	}
+
+	cases := []struct {
+		name       string
+		repoName   string
+		policy     *Policy
+		repository string
+		want       *pluginGitHubRepository
+		wantErr    string
+		wantReason Reason
+	}{
+		{
+			name:       "success",
+			repoName:   testIssueRepoName,
+			policy:     &Policy{},
+			repository: repository("public", true, true),
+			want:       wantInfo("public", true, true),
+		},
+		{
+			name:       "not_found",
+			repoName:   "missing-repo",
+			policy:     &Policy{},
+			wantErr:    "repository test-owner/missing-repo not found",
+			wantReason: ReasonReferenceNotFound,
+		},
+		{
+			name:     "server_error",
+			repoName: "broken-repo",
+			policy:   &Policy{},
+			wantErr:  "failed to get repository info",
+		},
+		{
+			name:       "visibility_allowed",
+			repoName:   testIssueRepoName,
+			policy:     &Policy{RepositoryVisibilities: []string{"private", "internal"}},
+			repository: repository("internal", false, false),
+			want:       wantInfo("internal", false, false),
+		},
+		{
+			name:       "visibility_not_allowed",
+			repoName:   testIssueRepoName,
+			policy:     &Policy{RepositoryVisibilities: []string{"private", "internal"}},
+			repository: repository("public", false, false),
+			want:       wantInfo("public", false, false),
+			wantErr:    `repository test-owner/test-repo is public, expected one of ["private" "internal"]`,
+			wantReason: ReasonRepoNotAllowed,
+		},
+		{
+			name:       "archived",
+			repoName:   testIssueRepoName,
+			policy:     &Policy{RepositoryDenyArchived: true},
+			repository: repository("private", true, false),
+			want:       wantInfo("private", true, false),
+			wantErr:    "repository test-owner/test-repo is archived",
+			wantReason: ReasonRepoNotAllowed,
+		},
+		{
+			name:       "archived_not_enforced",
+			repoName:   testIssueRepoName,
+			policy:     &Policy{RepositoryDenyArchived: true, Enforcement: []string{"REPO_NOT_ALLOWED=warn"}},
+			repository: repository("private", true, false),
+			want:       wantInfo("private", true, false),
+		},
+		{
+			name:       "fork",
+			repoName:   testIssueRepoName,
+			policy:     &Policy{RepositoryDenyForks: true},
+			repository: repository("private", false, true),
+			want:       wantInfo("private", false, true),
+			wantErr:    "repository test-owner/test-repo is a fork",
+			wantReason: ReasonRepoNotAllowed,
+		},
+		{
+			name:       "topics_present",
+			repoName:   testIssueRepoName,
+			policy:     &Policy{RepositoryTopics: []string{"Production-Service"}},
+			repository: repository("private", false, false),
+			want:       wantInfo("private", false, false),
+		},
+		{
+			name:       "topics_missing",
+			repoName:   testIssueRepoName,
+			policy:     &Policy{RepositoryTopics: []string{"production-service", "tier-1"}},
+			repository: repository("private", false, false),
+			want:       wantInfo("private", false, false),
+			wantErr:    `repository test-owner/test-repo is missing required topics ["tier-1"]`,
+			wantReason: ReasonRepoNotAllowed,
+		},
+		{
+			name:       "properties_present",
+			repoName:   testIssueRepoName,
+			policy:     &Policy{RepositoryProperties: []string{"tier=Critical", "regions=eu"}},
+			repository: repository("private", false, false),
+			want:       wantInfo("private", false, false),
+		},
+		{
+			name:       "property_mismatch",
+			repoName:   testIssueRepoName,
+			policy:     &Policy{RepositoryProperties: []string{"tier=critical", "owner-team=payments"}},
+			repository: repository("private", false, false),
+			want:       wantInfo("private", false, false),
+			wantErr:    `repository test-owner/test-repo custom property owner-team is [], expected "payments"`,
+			wantReason: ReasonRepoNotAllowed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			installation := testGitHubInstallation(t, http.StatusCreated)
+			hc := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case fmt.Sprintf("%s/%s/%s", issueRESTAPIPathPrefix, testIssueOwner, testIssueRepoName):
+					fmt.Fprint(w, tc.repository)
+				case fmt.Sprintf("%s/%s/%s/properties/values", issueRESTAPIPathPrefix, testIssueOwner, testIssueRepoName):
+					fmt.Fprint(w, `[
+						{"property_name": "tier", "value": "critical"},
+						{"property_name": "regions", "value": ["us", "eu"]},
+						{"property_name": "owner-team", "value": null}
+					]`)
+				case fmt.Sprintf("%s/%s/missing-repo", issueRESTAPIPathPrefix, testIssueOwner):
+					http.Error(w, "repository not found", http.StatusNotFound)
+				default:
+					http.Error(w, "injected server error", http.StatusInternalServerError)
+				}
+			})
+
+			v := NewValidator(github.NewClient(hc), installation, tc.policy)
+			got, err := v.MatchRepository(t.Context(), testIssueOwner, tc.repoName)
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if got, want := testReason(err), tc.wantReason; got != want {
+				t.Errorf("reason of %v got %q, want %q", err, got, want)
+			}
+			if tc.wantErr == "" || tc.want != nil {
+				if diff := cmp.Diff(tc.want, got); diff != "" {
+					t.Errorf("MatchRepository() unexpected diff (-want,+got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchRepository_Cache(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	installation := testGitHubInstallation(t, http.StatusCreated)
+	hc := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/properties/values") {
+			// User repositories don't support custom properties.
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		requests.Add(1)
+		fmt.Fprintf(w, `{"name": %q, "owner": {"login": %q}, "visibility": "private"}`, testIssueRepoName, testIssueOwner)
+	})
+
+	v := NewValidator(github.NewClient(hc), installation, &Policy{RepositoryCacheTTL: time.Minute})
+	for _, repoName := range []string{testIssueRepoName, "Test-Repo"} {
+		if _, err := v.MatchRepository(t.Context(), testIssueOwner, repoName); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := requests.Load(), int32(1); got != want {
+		t.Errorf("repository requests got %d, want %d", got, want)
+	}
+}
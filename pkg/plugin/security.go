@@ -0,0 +1,219 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/abcxyz/pkg/githubauth"
+)
+
+const (
+	securityURLPatternRegExp = `^https:\/\/github.com\/([a-zA-Z0-9-]*)\/[a-zA-Z0-9-]*\/security\/(advisories\/GHSA-[a-z0-9]{4}-[a-z0-9]{4}-[a-z0-9]{4}|dependabot\/[0-9]+|code-scanning\/[0-9]+)$`
+
+	// Kinds of security references, named after their URL path segment.
+	securityKindAdvisory     = "advisories"
+	securityKindDependabot   = "dependabot"
+	securityKindCodeScanning = "code-scanning"
+)
+
+// pluginGitHubSecurityReference contains the required attribute parsed from
+// the security advisory or alert URL, and the attributes used in annotations.
+type pluginGitHubSecurityReference struct {
+	Owner    string
+	RepoName string
+	// Kind is one of "advisories", "dependabot" or "code-scanning".
+	Kind string
+	// ID is the GHSA ID of advisories and the number of alerts.
+	ID string
+
+	State    string
+	Severity string
+}
+
+// repositoryAdvisory is the subset of a repository security advisory used for
+// validation. The client library doesn't support getting repository advisories.
+type repositoryAdvisory struct {
+	State    string `json:"state"`
+	Severity string `json:"severity"`
+}
+
+// MatchSecurityReference parses the advisory or alert from the provided URL
+// and validates that it is still being worked on: advisories must be in
+// triage or draft, and alerts must be open.
+func (v *Validator) MatchSecurityReference(ctx context.Context, securityURL string) (*pluginGitHubSecurityReference, error) {
+	info, err := parseSecurityInfoFromURL(securityURL)
+	if err != nil {
+		return nil, invalidf(ReasonReferenceMalformed, "failed to parse security url: %w", err)
+	}
+
+	t, err := v.getSecurityAccessToken(ctx, info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+	c := v.client.WithAuthToken(t)
+
+	var resp *github.Response
+	switch info.Kind {
+	case securityKindAdvisory:
+		resp, err = getRepositoryAdvisory(ctx, c, info)
+	case securityKindDependabot:
+		resp, err = getDependabotAlert(ctx, c, info)
+	case securityKindCodeScanning:
+		resp, err = getCodeScanningAlert(ctx, c, info)
+	}
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return info, invalidf(ReasonReferenceNotFound, "%s not found: %w", securityKindName(info.Kind), err)
+		}
+		return info, fmt.Errorf("failed to get %s info: %w", securityKindName(info.Kind), err)
+	}
+
+	switch info.Kind {
+	case securityKindAdvisory:
+		if info.State != "triage" && info.State != "draft" {
+			return info, invalidf(ReasonSecurityStateNotAllowed, "security advisory is in state: %s, please make sure to use an advisory in triage or draft", info.State)
+		}
+	default:
+		if info.State != "open" {
+			return info, invalidf(ReasonSecurityStateNotAllowed, "%s is in state: %s, please make sure to use an open alert", securityKindName(info.Kind), info.State)
+		}
+	}
+
+	return info, nil
+}
+
+// getRepositoryAdvisory gets the repository security advisory and records its
+// state and severity.
+func getRepositoryAdvisory(ctx context.Context, c *github.Client, info *pluginGitHubSecurityReference) (*github.Response, error) {
+	req, err := c.NewRequest(http.MethodGet,
+		fmt.Sprintf("repos/%s/%s/security-advisories/%s", info.Owner, info.RepoName, info.ID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create security advisory request: %w", err)
+	}
+	var advisory repositoryAdvisory
+	resp, err := c.Do(ctx, req, &advisory)
+	if err != nil {
+		return resp, err //nolint:wrapcheck // Wrapped by caller
+	}
+	info.State = advisory.State
+	info.Severity = advisory.Severity
+	return resp, nil
+}
+
+// getDependabotAlert gets the Dependabot alert and records its state and
+// severity.
+func getDependabotAlert(ctx context.Context, c *github.Client, info *pluginGitHubSecurityReference) (*github.Response, error) {
+	number, err := strconv.Atoi(info.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert alert number %s to int: %w", info.ID, err)
+	}
+	alert, resp, err := c.Dependabot.GetRepoAlert(ctx, info.Owner, info.RepoName, number)
+	if err != nil {
+		return resp, err //nolint:wrapcheck // Wrapped by caller
+	}
+	info.State = alert.GetState()
+	info.Severity = alert.GetSecurityAdvisory().GetSeverity()
+	return resp, nil
+}
+
+// getCodeScanningAlert gets the code scanning alert and records its state and
+// severity. The security severity is preferred over the rule severity, as only
+// security rules have it.
+func getCodeScanningAlert(ctx context.Context, c *github.Client, info *pluginGitHubSecurityReference) (*github.Response, error) {
+	number, err := strconv.ParseInt(info.ID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert alert number %s to int: %w", info.ID, err)
+	}
+	alert, resp, err := c.CodeScanning.GetAlert(ctx, info.Owner, info.RepoName, number)
+	if err != nil {
+		return resp, err //nolint:wrapcheck // Wrapped by caller
+	}
+	info.State = alert.GetState()
+	info.Severity = alert.GetRule().GetSecuritySeverityLevel()
+	if info.Severity == "" {
+		info.Severity = alert.GetRule().GetSeverity()
+	}
+	return resp, nil
+}
+
+// securityKindName returns the human readable name of the security reference
+// kind for error messages.
+func securityKindName(kind string) string {
+	switch kind {
+	case securityKindAdvisory:
+		return "security advisory"
+	case securityKindDependabot:
+		return "dependabot alert"
+	default:
+		return "code scanning alert"
+	}
+}
+
+// getSecurityAccessToken gets an access token with the read permission
+// matching the kind of the security reference.
+func (v *Validator) getSecurityAccessToken(ctx context.Context, info *pluginGitHubSecurityReference) (string, error) {
+	var permission string
+	switch info.Kind {
+	case securityKindAdvisory:
+		permission = "repository_advisories"
+	case securityKindDependabot:
+		permission = "vulnerability_alerts"
+	default:
+		permission = "security_events"
+	}
+
+	tr := &githubauth.TokenRequest{
+		Repositories: []string{info.RepoName},
+		Permissions: map[string]string{
+			permission: "read",
+		},
+	}
+
+	resp, err := v.githubInstallation.AccessToken(ctx, tr)
+	if err != nil {
+		return "", fmt.Errorf("failed to get access token: %w", err)
+	}
+	return resp, nil
+}
+
+// parseSecurityInfoFromURL parses pluginGitHubSecurityReference from a
+// security advisory, Dependabot alert or code scanning alert URL.
+func parseSecurityInfoFromURL(securityURL string) (*pluginGitHubSecurityReference, error) {
+	if match, _ := regexp.MatchString(securityURLPatternRegExp, securityURL); !match {
+		return nil, fmt.Errorf("invalid security url, %s", referenceHint(securityURL, referenceKindSecurity))
+	}
+	u, err := url.Parse(securityURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse provided security url: %w", err)
+	}
+
+	arr := strings.Split(u.Path, "/")
+	// len(arr) is not checked here as regexp.MatchString already covers this.
+	return &pluginGitHubSecurityReference{
+		Owner:    arr[1],
+		RepoName: arr[2],
+		Kind:     arr[4],
+		ID:       arr[5],
+	}, nil
+}
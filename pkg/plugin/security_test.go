@@ -0,0 +1,157 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v55/github"
+
+	"github.com/abcxyz/pkg/testutil"
+)
+
+const testGHSAID = "GHSA-abcd-efgh-ijkl"
+
+// testHandleSecurity returns a fake http func that serves the given response
+// for advisory testGHSAID, Dependabot alert 4 and code scanning alert 5 of the
+// test repo.
+func testHandleSecurity(tb testing.TB, response string) func(w http.ResponseWriter, r *http.Request) {
+	tb.Helper()
+	repoPath := fmt.Sprintf("%s/%s/%s", issueRESTAPIPathPrefix, testIssueOwner, testIssueRepoName)
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case repoPath + "/security-advisories/" + testGHSAID,
+			repoPath + "/dependabot/alerts/4",
+			repoPath + "/code-scanning/alerts/5":
+			fmt.Fprint(w, response)
+		case repoPath + "/dependabot/alerts/404":
+			http.Error(w, "alert not found", http.StatusNotFound)
+		default:
+			http.Error(w, "injected server error", http.StatusInternalServerError)
+		}
+	}
+}
+
+func TestMatchSecurityReference(t *testing.T) {
+	t.Parallel()
+
+	const repoURL = "https://github.com/test-owner/test-repo"
+
+	info := func(kind, id, state, severity string) *pluginGitHubSecurityReference {
+		return &pluginGitHubSecurityReference{
+			Owner:    testIssueOwner,
+			RepoName: testIssueRepoName,
+			Kind:     kind,
+			ID:       id,
+			State:    state,
+			Severity: severity,
+		}
+	}
+
+	cases := []struct {
+		name        string
+		securityURL string
+		response    string
+		want        *pluginGitHubSecurityReference
+		wantErr     string
+		wantReason  Reason
+	}{
+		{
+			name:        "advisory_triage",
+			securityURL: repoURL + "/security/advisories/" + testGHSAID,
+			response:    `{"state": "triage", "severity": "critical"}`,
+			want:        info("advisories", testGHSAID, "triage", "critical"),
+		},
+		{
+			name:        "advisory_published",
+			securityURL: repoURL + "/security/advisories/" + testGHSAID,
+			response:    `{"state": "published", "severity": "critical"}`,
+			want:        info("advisories", testGHSAID, "published", "critical"),
+			wantErr:     "security advisory is in state: published",
+			wantReason:  ReasonSecurityStateNotAllowed,
+		},
+		{
+			name:        "dependabot_open",
+			securityURL: repoURL + "/security/dependabot/4",
+			response:    `{"number": 4, "state": "open", "security_advisory": {"severity": "high"}}`,
+			want:        info("dependabot", "4", "open", "high"),
+		},
+		{
+			name:        "dependabot_fixed",
+			securityURL: repoURL + "/security/dependabot/4",
+			response:    `{"number": 4, "state": "fixed", "security_advisory": {"severity": "high"}}`,
+			want:        info("dependabot", "4", "fixed", "high"),
+			wantErr:     "dependabot alert is in state: fixed",
+			wantReason:  ReasonSecurityStateNotAllowed,
+		},
+		{
+			name:        "dependabot_not_found",
+			securityURL: repoURL + "/security/dependabot/404",
+			want:        info("dependabot", "404", "", ""),
+			wantErr:     "dependabot alert not found",
+			wantReason:  ReasonReferenceNotFound,
+		},
+		{
+			name:        "code_scanning_open",
+			securityURL: repoURL + "/security/code-scanning/5",
+			response:    `{"number": 5, "state": "open", "rule": {"severity": "error", "security_severity_level": "medium"}}`,
+			want:        info("code-scanning", "5", "open", "medium"),
+		},
+		{
+			name:        "code_scanning_rule_severity",
+			securityURL: repoURL + "/security/code-scanning/5",
+			response:    `{"number": 5, "state": "open", "rule": {"severity": "warning"}}`,
+			want:        info("code-scanning", "5", "open", "warning"),
+		},
+		{
+			name:        "code_scanning_server_error",
+			securityURL: repoURL + "/security/code-scanning/6",
+			want:        info("code-scanning", "6", "", ""),
+			wantErr:     "failed to get code scanning alert info",
+		},
+		{
+			name:        "invalid_url",
+			securityURL: repoURL + "/security/secret-scanning/1",
+			wantErr:     "invalid security url",
+			wantReason:  ReasonReferenceMalformed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			installation := testGitHubInstallation(t, http.StatusCreated)
+			hc := newTestServer(t, testHandleSecurity(t, tc.response))
+
+			v := NewValidator(github.NewClient(hc), installation, &Policy{})
+			got, err := v.MatchSecurityReference(t.Context(), tc.securityURL)
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if got, want := testReason(err), tc.wantReason; got != want {
+				t.Errorf("reason of %v got %q, want %q", err, got, want)
+			}
+			if tc.wantErr == "" || tc.want != nil {
+				if diff := cmp.Diff(tc.want, got); diff != "" {
+					t.Errorf("MatchSecurityReference() unexpected diff (-want,+got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+)
+
+const (
+	// issueSnapshotVersion is the version of the canonical issue serialisation.
+	// It must be bumped whenever the set or encoding of the fields changes, so
+	// hashes computed by older versions are never silently compared against
+	// hashes computed by newer ones.
+	issueSnapshotVersion = 1
+
+	// issueSnapshotHashPrefix is prepended to the hex encoded digest.
+	issueSnapshotHashPrefix = "sha256:"
+)
+
+// issueSnapshot is the canonical serialisation of the issue fields covered by
+// the snapshot hash. Field order is fixed by the struct definition.
+//
+// The last update time is not covered, since the audit comment posted by the
+// write-back updates it.
+type issueSnapshot struct {
+	Version   int      `json:"v"`
+	Title     string   `json:"title"`
+	Body      string   `json:"body"`
+	Labels    []string `json:"labels"`
+	State     string   `json:"state"`
+	Milestone string   `json:"milestone"`
+	Assignees []string `json:"assignees"`
+}
+
+// issueSnapshotHash computes a stable hash over the canonical serialisation of
+// the issue's title, body, labels, state, milestone and assignees. The
+// usedLabel added by the write-back is left out of the labels.
+func issueSnapshotHash(issue *github.Issue, usedLabel string) (string, error) {
+	labels := make([]string, 0, len(issue.Labels))
+	for _, l := range issue.Labels {
+		if usedLabel != "" && strings.EqualFold(l.GetName(), usedLabel) {
+			continue
+		}
+		labels = append(labels, l.GetName())
+	}
+	sort.Strings(labels)
+
+	assignees := make([]string, 0, len(issue.Assignees))
+	for _, a := range issue.Assignees {
+		assignees = append(assignees, strings.ToLower(a.GetLogin()))
+	}
+	sort.Strings(assignees)
+
+	b, err := json.Marshal(&issueSnapshot{
+		Version:   issueSnapshotVersion,
+		Title:     issue.GetTitle(),
+		Body:      issue.GetBody(),
+		Labels:    labels,
+		State:     issue.GetState(),
+		Milestone: issue.GetMilestone().GetTitle(),
+		Assignees: assignees,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to serialise issue snapshot: %w", err)
+	}
+
+	sum := sha256.Sum256(b)
+	return issueSnapshotHashPrefix + hex.EncodeToString(sum[:]), nil
+}
+
+// SnapshotVerification is the result of comparing the snapshot hash recorded
+// at validation time against the current content of the issue.
+type SnapshotVerification struct {
+	IssueURL     string
+	RecordedHash string
+	CurrentHash  string
+}
+
+// Changed reports whether the issue has changed since it was validated.
+func (s *SnapshotVerification) Changed() bool {
+	return s.RecordedHash != s.CurrentHash
+}
+
+// VerifyIssueSnapshot re-fetches the issues recorded in the given
+// justification annotations and compares their current snapshot hashes with
+// the recorded ones. Both the annotations of a single reference and the
+// indexed annotations of each reference of a justification with several
+// references are verified, in that order. Unlike MatchIssue, it does not
+// require the issues to still be open. It returns ErrMissingSnapshot if the
+// annotations do not carry a snapshot.
+func (v *Validator) VerifyIssueSnapshot(ctx context.Context, annotation map[string]string) ([]*SnapshotVerification, error) {
+	snapshots := recordedIssueSnapshots(annotation)
+	if len(snapshots) == 0 {
+		return nil, ErrMissingSnapshot
+	}
+
+	results := make([]*SnapshotVerification, 0, len(snapshots))
+	for _, s := range snapshots {
+		result, err := v.verifyIssueSnapshot(ctx, s.IssueURL, s.RecordedHash)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// recordedIssueSnapshots returns the issue URLs and snapshot hashes recorded in
+// the annotations, the single reference first and then the references of a
+// justification with several references by index.
+func recordedIssueSnapshots(annotation map[string]string) []*SnapshotVerification {
+	var snapshots []*SnapshotVerification
+	if issueURL, recorded := annotation[respAnnotationKeyIssueURL], annotation[respAnnotationKeyIssueSnapshotHash]; issueURL != "" && recorded != "" {
+		snapshots = append(snapshots, &SnapshotVerification{IssueURL: issueURL, RecordedHash: recorded})
+	}
+
+	// The annotations of each reference are keyed like
+	// "github_ref_0_issue_snapshot_hash", see respAnnotationKeyReferencePrefix.
+	urlSuffix := strings.TrimPrefix(respAnnotationKeyIssueURL, "github")
+	hashSuffix := strings.TrimPrefix(respAnnotationKeyIssueSnapshotHash, "github")
+	var indexes []int
+	for k := range annotation {
+		rest, ok := strings.CutPrefix(k, respAnnotationKeyReferencePrefix)
+		if !ok {
+			continue
+		}
+		i, ok := strings.CutSuffix(rest, hashSuffix)
+		if !ok {
+			continue
+		}
+		if n, err := strconv.Atoi(i); err == nil && n >= 0 {
+			indexes = append(indexes, n)
+		}
+	}
+	sort.Ints(indexes)
+	for _, i := range indexes {
+		prefix := respAnnotationKeyReferencePrefix + strconv.Itoa(i)
+		issueURL, recorded := annotation[prefix+urlSuffix], annotation[prefix+hashSuffix]
+		if issueURL != "" && recorded != "" {
+			snapshots = append(snapshots, &SnapshotVerification{IssueURL: issueURL, RecordedHash: recorded})
+		}
+	}
+	return snapshots
+}
+
+// verifyIssueSnapshot re-fetches the issue and compares its current snapshot
+// hash with the recorded one.
+func (v *Validator) verifyIssueSnapshot(ctx context.Context, issueURL, recorded string) (*SnapshotVerification, error) {
+	info, err := parseIssueInfoFromURL(issueURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse issueURL: %w", err)
+	}
+
+	t, err := v.getAccessToken(ctx, info.RepoName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+	c := v.client.WithAuthToken(t)
+
+	issue, _, err := c.Issues.Get(ctx, info.Owner, info.RepoName, info.IssueNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue info: %w", err)
+	}
+
+	current, err := issueSnapshotHash(issue, v.usedLabel)
+	if err != nil {
+		return nil, err
+	}
+	return &SnapshotVerification{
+		IssueURL:     issueURL,
+		RecordedHash: recorded,
+		CurrentHash:  current,
+	}, nil
+}
@@ -0,0 +1,338 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v55/github"
+
+	"github.com/abcxyz/pkg/testutil"
+)
+
+// testOpenIssueSnapshotHash is the snapshot hash of an issue that only has its
+// state set to open. It is hardcoded so changes to the canonical serialisation
+// are caught.
+const testOpenIssueSnapshotHash = "sha256:6ec91ac04de3e572b343b0a3390c024a1758ae8e8317128c0384f6f517edc133"
+
+func TestIssueSnapshotHash(t *testing.T) {
+	t.Parallel()
+
+	updatedAt := &github.Timestamp{Time: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)}
+	base := func() *github.Issue {
+		return &github.Issue{
+			Title:     github.String("outage"),
+			Body:      github.String("details"),
+			State:     github.String("open"),
+			UpdatedAt: updatedAt,
+			Labels: []*github.Label{
+				{Name: github.String("b")},
+				{Name: github.String("a")},
+			},
+			Milestone: &github.Milestone{Title: github.String("Q3 Incidents")},
+			Assignees: []*github.User{
+				{Login: github.String("octocat")},
+				{Login: github.String("hubot")},
+			},
+		}
+	}
+
+	baseHash, err := issueSnapshotHash(base(), "jvs:used")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name        string
+		modify      func(i *github.Issue)
+		wantChanged bool
+	}{
+		{
+			name:   "unchanged",
+			modify: func(i *github.Issue) {},
+		},
+		{
+			name: "label_order",
+			modify: func(i *github.Issue) {
+				i.Labels[0], i.Labels[1] = i.Labels[1], i.Labels[0]
+			},
+		},
+		{
+			name: "ignored_field",
+			modify: func(i *github.Issue) {
+				i.Comments = github.Int(5)
+			},
+		},
+		{
+			name: "updated_at",
+			modify: func(i *github.Issue) {
+				i.UpdatedAt = &github.Timestamp{Time: updatedAt.Add(time.Second)}
+			},
+		},
+		{
+			name: "used_label",
+			modify: func(i *github.Issue) {
+				i.Labels = append(i.Labels, &github.Label{Name: github.String("JVS:used")})
+			},
+		},
+		{
+			name: "assignee_order",
+			modify: func(i *github.Issue) {
+				i.Assignees[0], i.Assignees[1] = i.Assignees[1], i.Assignees[0]
+			},
+		},
+		{
+			name: "title",
+			modify: func(i *github.Issue) {
+				i.Title = github.String("other")
+			},
+			wantChanged: true,
+		},
+		{
+			name: "body",
+			modify: func(i *github.Issue) {
+				i.Body = github.String("")
+			},
+			wantChanged: true,
+		},
+		{
+			name: "labels",
+			modify: func(i *github.Issue) {
+				i.Labels = i.Labels[:1]
+			},
+			wantChanged: true,
+		},
+		{
+			name: "state",
+			modify: func(i *github.Issue) {
+				i.State = github.String("closed")
+			},
+			wantChanged: true,
+		},
+		{
+			name: "milestone",
+			modify: func(i *github.Issue) {
+				i.Milestone = nil
+			},
+			wantChanged: true,
+		},
+		{
+			name: "assignees",
+			modify: func(i *github.Issue) {
+				i.Assignees = i.Assignees[1:]
+			},
+			wantChanged: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			issue := base()
+			tc.modify(issue)
+			got, err := issueSnapshotHash(issue, "jvs:used")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if changed := got != baseHash; changed != tc.wantChanged {
+				t.Errorf("issueSnapshotHash() changed = %t, want %t", changed, tc.wantChanged)
+			}
+		})
+	}
+}
+
+func TestVerifyIssueSnapshot(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		annotation  map[string]string
+		wantResult  []*SnapshotVerification
+		wantChanged []bool
+		wantErr     string
+		wantMissing bool
+	}{
+		{
+			name: "unchanged",
+			annotation: map[string]string{
+				respAnnotationKeyIssueURL:          testGitHubIssueURL,
+				respAnnotationKeyIssueSnapshotHash: testOpenIssueSnapshotHash,
+			},
+			wantResult: []*SnapshotVerification{{
+				IssueURL:     testGitHubIssueURL,
+				RecordedHash: testOpenIssueSnapshotHash,
+				CurrentHash:  testOpenIssueSnapshotHash,
+			}},
+			wantChanged: []bool{false},
+		},
+		{
+			name: "changed",
+			annotation: map[string]string{
+				respAnnotationKeyIssueURL:          testGitHubIssueURL,
+				respAnnotationKeyIssueSnapshotHash: "sha256:old",
+			},
+			wantResult: []*SnapshotVerification{{
+				IssueURL:     testGitHubIssueURL,
+				RecordedHash: "sha256:old",
+				CurrentHash:  testOpenIssueSnapshotHash,
+			}},
+			wantChanged: []bool{true},
+		},
+		{
+			name: "multiple_references",
+			annotation: map[string]string{
+				"github_ref_0_url":                  testGitHubIssueURL,
+				"github_ref_0_issue_url":            testGitHubIssueURL,
+				"github_ref_0_issue_snapshot_hash":  "sha256:old",
+				"github_ref_1_url":                  "https://github.com/test-owner/test-repo/pull/1",
+				"github_ref_1_pull_request_url":     "https://github.com/test-owner/test-repo/pull/1",
+				"github_ref_10_url":                 testGitHubIssueURL,
+				"github_ref_10_issue_url":           testGitHubIssueURL,
+				"github_ref_10_issue_snapshot_hash": testOpenIssueSnapshotHash,
+			},
+			wantResult: []*SnapshotVerification{
+				{
+					IssueURL:     testGitHubIssueURL,
+					RecordedHash: "sha256:old",
+					CurrentHash:  testOpenIssueSnapshotHash,
+				},
+				{
+					IssueURL:     testGitHubIssueURL,
+					RecordedHash: testOpenIssueSnapshotHash,
+					CurrentHash:  testOpenIssueSnapshotHash,
+				},
+			},
+			wantChanged: []bool{true, false},
+		},
+		{
+			name: "missing_snapshot",
+			annotation: map[string]string{
+				respAnnotationKeyIssueURL: testGitHubIssueURL,
+				"github_ref_0_issue_url":  testGitHubIssueURL,
+			},
+			wantErr:     "missing issue snapshot",
+			wantMissing: true,
+		},
+		{
+			name: "issue_not_found",
+			annotation: map[string]string{
+				respAnnotationKeyIssueURL:          "https://github.com/test-owner/test-repo/issues/2",
+				respAnnotationKeyIssueSnapshotHash: testOpenIssueSnapshotHash,
+			},
+			wantErr: "failed to get issue info",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			installation := testGitHubInstallation(t, http.StatusCreated)
+			hc := newTestServer(t, testHandleIssueReturn(t, []byte(`{"state": "open"}`)))
+			v := NewValidator(github.NewClient(hc), installation, &Policy{})
+
+			got, err := v.VerifyIssueSnapshot(t.Context(), tc.annotation)
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if got, want := errors.Is(err, ErrMissingSnapshot), tc.wantMissing; got != want {
+				t.Errorf("errors.Is(%v, ErrMissingSnapshot) = %t, want %t", err, got, want)
+			}
+			if diff := cmp.Diff(tc.wantResult, got); diff != "" {
+				t.Errorf("VerifyIssueSnapshot() unexpected diff (-want,+got):\n%s", diff)
+			}
+			var changed []bool
+			for _, r := range got {
+				changed = append(changed, r.Changed())
+			}
+			if diff := cmp.Diff(tc.wantChanged, changed); diff != "" {
+				t.Errorf("Changed() unexpected diff (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestVerifyIssueSnapshot_AfterWriteBack(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		cfg         *PluginConfig
+		edit        func(i *github.Issue)
+		wantChanged bool
+	}{
+		{
+			name: "comment_and_label",
+			cfg:  &PluginConfig{GitHubAuditComment: true, GitHubUsedLabel: "jvs:used"},
+		},
+		{
+			name: "edited_after_write_back",
+			cfg:  &PluginConfig{GitHubAuditComment: true, GitHubUsedLabel: "jvs:used"},
+			edit: func(i *github.Issue) {
+				i.Body = github.String("edited")
+			},
+			wantChanged: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := &testWriteBackServer{
+				issue: &github.Issue{
+					Title:     github.String("outage"),
+					Body:      github.String("details"),
+					State:     github.String("open"),
+					UpdatedAt: &github.Timestamp{Time: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)},
+				},
+				labels: []*github.Label{{Name: github.String("incident")}},
+			}
+			hc := newTestServer(t, srv.handle(t))
+			installation := testGitHubInstallation(t, http.StatusCreated)
+
+			v := NewValidator(github.NewClient(hc), installation, &Policy{})
+			v.SetUsedLabel(tc.cfg.GitHubUsedLabel)
+			info, err := v.MatchIssue(t.Context(), testGitHubIssueURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := NewIssueWriter(github.NewClient(hc), installation, tc.cfg)
+			if err := w.WriteBack(t.Context(), info, &auditEntry{Time: time.Now()}); err != nil {
+				t.Fatal(err)
+			}
+			if tc.edit != nil {
+				tc.edit(srv.issue)
+			}
+
+			results, err := v.VerifyIssueSnapshot(t.Context(), issueAnnotations(info, testGitHubIssueURL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(results) != 1 {
+				t.Fatalf("VerifyIssueSnapshot() got %d results, want 1", len(results))
+			}
+			if got := results[0]; got.Changed() != tc.wantChanged {
+				t.Errorf("Changed() = %t, want %t, recorded %s, current %s", got.Changed(), tc.wantChanged, got.RecordedHash, got.CurrentHash)
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/abcxyz/pkg/githubauth"
+	"github.com/abcxyz/pkg/logging"
+)
+
+const (
+	// maxSuggestionCandidates bounds the number of issues searched for and
+	// validated per suggestion request.
+	maxSuggestionCandidates = 5
+
+	// defaultSuggestionLimit is the number of suggestions returned by default,
+	// and maxSuggestionLimit the most that can be requested.
+	defaultSuggestionLimit = maxSuggestionCandidates
+	maxSuggestionLimit     = maxSuggestionCandidates
+
+	// suggestionCacheTTL is how long the suggestions for a login and query
+	// are cached.
+	suggestionCacheTTL = time.Minute
+
+	// defaultSuggestRateLimit is the number of suggestion requests allowed per
+	// requester per minute by default.
+	defaultSuggestRateLimit = 30
+
+	// defaultSuggestGlobalRateLimit is the number of suggestion requests
+	// allowed per minute across all requesters by default.
+	defaultSuggestGlobalRateLimit = 300
+
+	// maxGitHubLoginLength is the maximum length of GitHub logins.
+	maxGitHubLoginLength = 39
+
+	// suggestTimeout bounds the duration of a suggestion request.
+	suggestTimeout = 20 * time.Second
+
+	// defaultSuggestHost is the host the suggestion endpoint listens on by
+	// default, so it is only reachable from the same host.
+	defaultSuggestHost = "127.0.0.1"
+)
+
+// githubLoginRegExp matches GitHub logins, alphanumeric characters and single
+// hyphens, which can't start or end the login.
+var githubLoginRegExp = regexp.MustCompile(`^[a-zA-Z0-9]+(-[a-zA-Z0-9]+)*$`)
+
+// isGitHubLogin reports whether s is a well-formed GitHub login.
+func isGitHubLogin(s string) bool {
+	return len(s) <= maxGitHubLoginLength && githubLoginRegExp.MatchString(s)
+}
+
+// isLoopbackHost reports whether the host only accepts connections from the
+// same host.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Suggestion is an issue which would pass validation.
+type Suggestion struct {
+	URL   string `json:"url"`
+	Title string `json:"title"`
+	// Repository is in the "owner/repo" format.
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+}
+
+// issueSuggester is the mockable interface for suggesting issues.
+type issueSuggester interface {
+	SuggestIssues(ctx context.Context, owners []string, query, login string, limit int) ([]*Suggestion, error)
+}
+
+// SuggestIssues searches the open issues of the owners involving the login and
+// matching the query, and returns up to limit of them which pass the checks of
+// matchSuggestion, most recently updated first. The suggestions are cached per
+// login and query.
+func (v *Validator) SuggestIssues(ctx context.Context, owners []string, query, login string, limit int) ([]*Suggestion, error) {
+	// Logins are case insensitive.
+	key := strings.Join([]string{strings.ToLower(login), strings.Join(owners, ","), query}, "\x00")
+	suggestions, ok := v.suggestions.Lookup(key)
+	if !ok {
+		var err error
+		if suggestions, err = v.searchSuggestions(ctx, owners, query, login); err != nil {
+			return nil, err
+		}
+		v.suggestions.Set(key, suggestions)
+	}
+	return suggestions[:min(limit, len(suggestions))], nil
+}
+
+// searchSuggestions searches the open issues of the owners involving the login
+// and matching the query, and returns those which pass the checks of
+// matchSuggestion.
+func (v *Validator) searchSuggestions(ctx context.Context, owners []string, query, login string) ([]*Suggestion, error) {
+	t, err := v.githubInstallation.AccessTokenAllRepos(ctx, &githubauth.TokenRequestAllRepos{
+		Permissions: map[string]string{
+			"issues": "read",
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+	c := v.client.WithAuthToken(t)
+
+	result, _, err := c.Search.Issues(ctx, suggestionSearchQuery(owners, query, login), &github.SearchOptions{
+		Sort:        "updated",
+		Order:       "desc",
+		ListOptions: github.ListOptions{PerPage: maxSuggestionCandidates},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+
+	suggestions := make([]*Suggestion, 0, len(result.Issues))
+	for _, issue := range result.Issues {
+		info, err := parseIssueInfoFromURL(issue.GetHTMLURL())
+		if err != nil {
+			continue
+		}
+		if err := v.matchSuggestion(ctx, info, issue); err != nil {
+			if errors.Is(err, errInvalidJustification) {
+				continue
+			}
+			return nil, err
+		}
+		suggestions = append(suggestions, &Suggestion{
+			URL:        issue.GetHTMLURL(),
+			Title:      issue.GetTitle(),
+			Repository: info.Owner + "/" + info.RepoName,
+			Number:     info.IssueNumber,
+		})
+	}
+	return suggestions, nil
+}
+
+// matchSuggestion validates the issue found by the search with the checks
+// which don't need GitHub API calls for each issue: its state, repository,
+// required labels, milestone and body. The search results include the issue
+// content, and repository metadata is cached. The other checks, like team
+// memberships or approvals, are left to the validation of the justification,
+// so suggestions may still be rejected.
+func (v *Validator) matchSuggestion(ctx context.Context, info *pluginGitHubIssue, issue *github.Issue) error {
+	if s := issue.GetState(); s != "open" {
+		return invalidf(ReasonIssueClosed, "issue is in state: %s", s)
+	}
+	if v.policy.hasRepositoryPolicy() {
+		if _, err := v.MatchRepository(ctx, info.Owner, info.RepoName); err != nil {
+			return err
+		}
+	}
+	return v.runIssueChecks(ctx, []*issueCheck{
+		{ReasonLabelMissing, "", func() error { return v.validateIssuePropertyLabels(ctx, info, issue) }},
+		{ReasonMilestoneNotAllowed, ruleIssueMilestones, func() error { return v.validateIssueMilestones(issue) }},
+		{ReasonMilestoneNotAllowed, ruleIssueRequireOpenMilestone, func() error { return v.validateIssueOpenMilestone(issue) }},
+		{ReasonIssueBodyInvalid, ruleIssueRequiredSections, func() error { return v.validateIssueSections(issue.GetBody()) }},
+		{ReasonIssueBodyInvalid, ruleIssueBodyPatterns, func() error { return v.validateIssueBodyPatterns(issue.GetBody()) }},
+	})
+}
+
+// suggestionSearchQuery builds the issue search query. Search qualifiers in
+// the user query are neutralized by quoting it, so it can't widen the search
+// beyond the owners. The login must be validated with isGitHubLogin, so it
+// can't add qualifiers either.
+func suggestionSearchQuery(owners []string, query, login string) string {
+	parts := []string{"is:issue", "is:open", "involves:" + login}
+	for _, owner := range owners {
+		parts = append(parts, "user:"+owner)
+	}
+	if query = strings.TrimSpace(strings.ReplaceAll(query, `"`, " ")); query != "" {
+		parts = append(parts, strconv.Quote(query))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Suggest suggests issues passing validation in the category.
+func (g *GitHubPlugin) Suggest(ctx context.Context, category, query, login string, limit int) ([]*Suggestion, error) {
+	c, ok := g.categories[category]
+	if !ok || c.suggester == nil {
+		return nil, invalidf(ReasonCategoryUnknown, "category %q is not configured", category)
+	}
+	return c.suggester.SuggestIssues(ctx, g.suggestOwners, query, login, limit) //nolint:wrapcheck // Want passthrough
+}
+
+// suggestResponse is the response of the suggestion endpoint.
+type suggestResponse struct {
+	Suggestions []*Suggestion `json:"suggestions,omitempty"`
+	Error       string        `json:"error,omitempty"`
+}
+
+// NewSuggestHandler returns the HTTP handler of the suggestion endpoint, which
+// serves GET requests with the "category", "login", "q" and "limit" query
+// parameters. Requests are rate limited per login to ratePerMinute, and to
+// globalRatePerMinute across all logins.
+//
+// The login is not authenticated, like the requester annotation, so callers
+// must be trusted to pass the login of their user. When token is set, callers
+// must authenticate with it as a bearer token. The global limit bounds the
+// GitHub API calls made on behalf of logins chosen by the caller.
+func NewSuggestHandler(p *GitHubPlugin, token string, ratePerMinute, globalRatePerMinute int) http.Handler {
+	limiter := newRateLimiter(ratePerMinute, time.Minute)
+	globalLimiter := newRateLimiter(globalRatePerMinute, time.Minute)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), suggestTimeout)
+		defer cancel()
+
+		if token != "" && !isSuggestToken(r, token) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeSuggestResponse(ctx, w, http.StatusUnauthorized, &suggestResponse{Error: "unauthorized"})
+			return
+		}
+
+		if r.Method != http.MethodGet {
+			writeSuggestResponse(ctx, w, http.StatusMethodNotAllowed, &suggestResponse{Error: "method not allowed"})
+			return
+		}
+
+		q := r.URL.Query()
+		category := q.Get("category")
+		if category == "" {
+			category = githubCategory
+		}
+		login := q.Get("login")
+		if login == "" {
+			writeSuggestResponse(ctx, w, http.StatusBadRequest, &suggestResponse{Error: "login is required"})
+			return
+		}
+		if !isGitHubLogin(login) {
+			writeSuggestResponse(ctx, w, http.StatusBadRequest, &suggestResponse{Error: "login is not a valid GitHub login"})
+			return
+		}
+		limit := defaultSuggestionLimit
+		if s := q.Get("limit"); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil || n <= 0 || n > maxSuggestionLimit {
+				writeSuggestResponse(ctx, w, http.StatusBadRequest,
+					&suggestResponse{Error: fmt.Sprintf("limit must be between 1 and %d", maxSuggestionLimit)})
+				return
+			}
+			limit = n
+		}
+
+		// The global limit is checked last, so requests rejected per login
+		// don't consume it.
+		if !limiter.Allow(strings.ToLower(login)) || !globalLimiter.Allow("") {
+			writeSuggestResponse(ctx, w, http.StatusTooManyRequests, &suggestResponse{Error: "rate limit exceeded"})
+			return
+		}
+
+		suggestions, err := p.Suggest(ctx, category, q.Get("q"), login, limit)
+		if err != nil {
+			if errors.Is(err, errInvalidJustification) {
+				writeSuggestResponse(ctx, w, http.StatusNotFound, &suggestResponse{Error: err.Error()})
+				return
+			}
+			if errors.Is(err, errCircuitOpen) {
+				writeSuggestResponse(ctx, w, http.StatusServiceUnavailable, &suggestResponse{Error: "github is unavailable"})
+				return
+			}
+			logging.FromContext(ctx).ErrorContext(ctx, "failed to suggest issues",
+				"category", category,
+				"login", login,
+				"error", err)
+			writeSuggestResponse(ctx, w, http.StatusInternalServerError, &suggestResponse{Error: "failed to suggest issues"})
+			return
+		}
+		writeSuggestResponse(ctx, w, http.StatusOK, &suggestResponse{Suggestions: suggestions})
+	})
+}
+
+// isSuggestToken reports whether the request is authenticated with the bearer
+// token, compared in constant time.
+func isSuggestToken(r *http.Request, token string) bool {
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1
+}
+
+// writeSuggestResponse writes the JSON response.
+func writeSuggestResponse(ctx context.Context, w http.ResponseWriter, code int, resp *suggestResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to write suggestion response", "error", err)
+	}
+}
@@ -0,0 +1,396 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v55/github"
+
+	"github.com/abcxyz/pkg/testutil"
+)
+
+type testIssueSuggester struct {
+	gotQuery string
+	gotLogin string
+	gotLimit int
+	rErr     error
+}
+
+func (t *testIssueSuggester) SuggestIssues(ctx context.Context, owners []string, query, login string, limit int) ([]*Suggestion, error) {
+	t.gotQuery, t.gotLogin, t.gotLimit = query, login, limit
+	if t.rErr != nil {
+		return nil, t.rErr
+	}
+	return []*Suggestion{{URL: testGitHubIssueURL, Title: "Outage", Repository: "test-owner/test-repo", Number: 1}}, nil
+}
+
+func TestSuggestionSearchQuery(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name: "no_query",
+			want: `is:issue is:open involves:octocat user:my-org user:octocat`,
+		},
+		{
+			name:  "query",
+			query: " database outage ",
+			want:  `is:issue is:open involves:octocat user:my-org user:octocat "database outage"`,
+		},
+		{
+			name:  "qualifiers_are_quoted",
+			query: `" user:other-org "`,
+			want:  `is:issue is:open involves:octocat user:my-org user:octocat "user:other-org"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := suggestionSearchQuery([]string{"my-org", "octocat"}, tc.query, "octocat"); got != tc.want {
+				t.Errorf("suggestionSearchQuery() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSuggestIssues(t *testing.T) {
+	t.Parallel()
+
+	installation := testGitHubInstallation(t, http.StatusCreated)
+	var searches int
+	hc := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/search/issues":
+			searches++
+			if got, want := r.URL.Query().Get("q"), `is:issue is:open involves:octocat user:test-owner "outage"`; got != want {
+				t.Errorf("search query got %q, want %q", got, want)
+			}
+			fmt.Fprint(w, `{"items": [
+				{"html_url": "https://github.com/test-owner/test-repo/issues/1", "title": "Outage", "state": "open"},
+				{"html_url": "https://github.com/test-owner/test-repo/issues/2", "title": "Closed outage", "state": "closed"},
+				{"html_url": "https://github.com/test-owner/test-repo/pull/3", "title": "Fix outage", "state": "open"},
+				{"html_url": "https://github.com/test-owner/test-repo/issues/4", "title": "Another outage", "state": "open"}
+			]}`)
+		case fmt.Sprintf("%s/%s/%s", issueRESTAPIPathPrefix, testIssueOwner, testIssueRepoName):
+			fmt.Fprintf(w, `{"name": %q, "owner": {"login": %q}, "visibility": "private"}`, testIssueRepoName, testIssueOwner)
+		case fmt.Sprintf("%s/%s/%s/properties/values", issueRESTAPIPathPrefix, testIssueOwner, testIssueRepoName):
+			fmt.Fprint(w, `[]`)
+		default:
+			// Suggestions don't fetch the issues.
+			http.Error(w, "injected server error", http.StatusInternalServerError)
+		}
+	})
+
+	v := NewValidator(github.NewClient(hc), installation, &Policy{})
+	got, err := v.SuggestIssues(t.Context(), []string{testIssueOwner}, "outage", "octocat", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Suggestion{
+		{URL: "https://github.com/test-owner/test-repo/issues/1", Title: "Outage", Repository: "test-owner/test-repo", Number: 1},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SuggestIssues() unexpected diff (-want,+got):\n%s", diff)
+	}
+
+	got, err = v.SuggestIssues(t.Context(), []string{testIssueOwner}, "outage", "octocat", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = append(want, &Suggestion{URL: "https://github.com/test-owner/test-repo/issues/4", Title: "Another outage", Repository: "test-owner/test-repo", Number: 4})
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SuggestIssues() unexpected diff (-want,+got):\n%s", diff)
+	}
+
+	// The login is case insensitive, so the suggestions are cached.
+	if _, err := v.SuggestIssues(t.Context(), []string{testIssueOwner}, "outage", "OctoCat", 5); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := searches, 1; got != want {
+		t.Errorf("searches got %d, want %d", got, want)
+	}
+}
+
+func TestSuggestHandler(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		method     string
+		target     string
+		suggestErr error
+		wantCode   int
+		wantResp   *suggestResponse
+		wantLimit  int
+	}{
+		{
+			name:     "success",
+			target:   "/?login=octocat&q=outage",
+			wantCode: http.StatusOK,
+			wantResp: &suggestResponse{Suggestions: []*Suggestion{
+				{URL: testGitHubIssueURL, Title: "Outage", Repository: "test-owner/test-repo", Number: 1},
+			}},
+			wantLimit: defaultSuggestionLimit,
+		},
+		{
+			name:      "limit",
+			target:    "/?category=github&login=octocat&limit=3",
+			wantCode:  http.StatusOK,
+			wantResp:  &suggestResponse{Suggestions: []*Suggestion{{URL: testGitHubIssueURL, Title: "Outage", Repository: "test-owner/test-repo", Number: 1}}},
+			wantLimit: 3,
+		},
+		{
+			name:     "method_not_allowed",
+			method:   http.MethodPost,
+			target:   "/?login=octocat",
+			wantCode: http.StatusMethodNotAllowed,
+			wantResp: &suggestResponse{Error: "method not allowed"},
+		},
+		{
+			name:     "missing_login",
+			target:   "/?q=outage",
+			wantCode: http.StatusBadRequest,
+			wantResp: &suggestResponse{Error: "login is required"},
+		},
+		{
+			name:     "invalid_login",
+			target:   "/?login=octocat+is:closed",
+			wantCode: http.StatusBadRequest,
+			wantResp: &suggestResponse{Error: "login is not a valid GitHub login"},
+		},
+		{
+			name:     "invalid_limit",
+			target:   "/?login=octocat&limit=100",
+			wantCode: http.StatusBadRequest,
+			wantResp: &suggestResponse{Error: "limit must be between 1 and 5"},
+		},
+		{
+			name:     "unknown_category",
+			target:   "/?category=github-pr&login=octocat",
+			wantCode: http.StatusNotFound,
+			wantResp: &suggestResponse{Error: `invalid justification: category "github-pr" is not configured`},
+		},
+		{
+			name:       "internal_error",
+			target:     "/?login=octocat",
+			suggestErr: fmt.Errorf("injected error"),
+			wantCode:   http.StatusInternalServerError,
+			wantResp:   &suggestResponse{Error: "failed to suggest issues"},
+			wantLimit:  defaultSuggestionLimit,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			suggester := &testIssueSuggester{rErr: tc.suggestErr}
+			p := &GitHubPlugin{
+				categories: map[string]*pluginCategory{
+					githubCategory: {suggester: suggester},
+				},
+			}
+			method := tc.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			w := httptest.NewRecorder()
+			NewSuggestHandler(p, "", defaultSuggestRateLimit, defaultSuggestGlobalRateLimit).ServeHTTP(w, httptest.NewRequest(method, tc.target, nil))
+
+			if got, want := w.Code, tc.wantCode; got != want {
+				t.Errorf("status code got %d, want %d", got, want)
+			}
+			var got suggestResponse
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.wantResp, &got); diff != "" {
+				t.Errorf("response unexpected diff (-want,+got):\n%s", diff)
+			}
+			if got, want := suggester.gotLimit, tc.wantLimit; got != want {
+				t.Errorf("limit got %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestSuggestHandler_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	p := &GitHubPlugin{
+		categories: map[string]*pluginCategory{
+			githubCategory: {suggester: &testIssueSuggester{}},
+		},
+	}
+	h := NewSuggestHandler(p, "", 2, 4)
+
+	var codes []int
+	for _, login := range []string{"octocat", "OctoCat", "octocat", "hubot", "alice", "bob"} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?login="+login, nil))
+		codes = append(codes, w.Code)
+	}
+	// The third octocat request exceeds the limit per login, and the bob
+	// request the global limit.
+	want := []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests, http.StatusOK, http.StatusOK, http.StatusTooManyRequests}
+	if diff := cmp.Diff(want, codes); diff != "" {
+		t.Errorf("status codes unexpected diff (-want,+got):\n%s", diff)
+	}
+}
+
+func TestSuggestHandler_Token(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name          string
+		authorization string
+		wantCode      int
+	}{
+		{
+			name:          "valid_token",
+			authorization: "Bearer my-secret",
+			wantCode:      http.StatusOK,
+		},
+		{
+			name:     "missing_token",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:          "wrong_token",
+			authorization: "Bearer not-my-secret",
+			wantCode:      http.StatusUnauthorized,
+		},
+		{
+			name:          "not_bearer",
+			authorization: "my-secret",
+			wantCode:      http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			suggester := &testIssueSuggester{}
+			p := &GitHubPlugin{
+				categories: map[string]*pluginCategory{
+					githubCategory: {suggester: suggester},
+				},
+			}
+
+			r := httptest.NewRequest(http.MethodGet, "/?login=octocat", nil)
+			if tc.authorization != "" {
+				r.Header.Set("Authorization", tc.authorization)
+			}
+			w := httptest.NewRecorder()
+			NewSuggestHandler(p, "my-secret", defaultSuggestRateLimit, defaultSuggestGlobalRateLimit).ServeHTTP(w, r)
+
+			if got, want := w.Code, tc.wantCode; got != want {
+				t.Errorf("status code got %d, want %d", got, want)
+			}
+			if tc.wantCode == http.StatusUnauthorized && suggester.gotLimit != 0 {
+				t.Errorf("unauthorized request got suggestions")
+			}
+		})
+	}
+}
+
+func TestIsLoopbackHost(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		host string
+		want bool
+	}{
+		{host: "127.0.0.1", want: true},
+		{host: "::1", want: true},
+		{host: "localhost", want: true},
+		{host: "0.0.0.0"},
+		{host: "10.0.0.1"},
+		{host: "example.com"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.host, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isLoopbackHost(tc.host); got != tc.want {
+				t.Errorf("isLoopbackHost(%q) got %t, want %t", tc.host, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsGitHubLogin(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		login string
+		want  bool
+	}{
+		{name: "simple", login: "octocat", want: true},
+		{name: "hyphen", login: "octo-cat", want: true},
+		{name: "digits", login: "0ctocat1", want: true},
+		{name: "max_length", login: strings.Repeat("a", 39), want: true},
+		{name: "too_long", login: strings.Repeat("a", 40)},
+		{name: "leading_hyphen", login: "-octocat"},
+		{name: "trailing_hyphen", login: "octocat-"},
+		{name: "double_hyphen", login: "octo--cat"},
+		{name: "space", login: "octocat is:closed"},
+		{name: "qualifier", login: "octocat+repo:secret/repo"},
+		{name: "quote", login: `octocat"`},
+		{name: "empty", login: ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isGitHubLogin(tc.login); got != tc.want {
+				t.Errorf("isGitHubLogin(%q) got %t, want %t", tc.login, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGitHubPlugin_Suggest(t *testing.T) {
+	t.Parallel()
+
+	p := &GitHubPlugin{
+		categories: map[string]*pluginCategory{
+			githubCategory: {},
+		},
+	}
+	_, err := p.Suggest(t.Context(), githubCategory, "", "octocat", 1)
+	if diff := testutil.DiffErrString(err, `category "github" is not configured`); diff != "" {
+		t.Error(diff)
+	}
+}
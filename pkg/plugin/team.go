@@ -0,0 +1,180 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/abcxyz/pkg/cache"
+	"github.com/abcxyz/pkg/githubauth"
+)
+
+// defaultTeamCacheTTL is how long team memberships are cached by default.
+const defaultTeamCacheTTL = 5 * time.Minute
+
+// teamMembershipBackend reports whether a user is an active member of a team.
+type teamMembershipBackend interface {
+	IsTeamMember(ctx context.Context, org, slug, login string) (bool, error)
+}
+
+// gitHubTeamBackend implements teamMembershipBackend with the GitHub API.
+type gitHubTeamBackend struct {
+	client             *github.Client
+	githubInstallation *githubauth.AppInstallation
+}
+
+// IsTeamMember reports whether the user is an active member of the team, using
+// an installation token with organization members read permission.
+func (b *gitHubTeamBackend) IsTeamMember(ctx context.Context, org, slug, login string) (bool, error) {
+	t, err := b.githubInstallation.AccessTokenAllRepos(ctx, &githubauth.TokenRequestAllRepos{
+		Permissions: map[string]string{
+			"members": "read",
+		},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get access token: %w", err)
+	}
+	c := b.client.WithAuthToken(t)
+
+	m, resp, err := c.Teams.GetTeamMembershipBySlug(ctx, org, slug, login)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			// GitHub also responds with a 404 when the team doesn't exist or
+			// can't be read, which must not be mistaken for a non-member.
+			if err := b.checkTeam(ctx, c, org, slug); err != nil {
+				return false, err
+			}
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get %s membership of team %s/%s: %w", login, org, slug, err)
+	}
+	return m.GetState() == "active", nil
+}
+
+// checkTeam verifies the team exists and can be read with the token.
+func (b *gitHubTeamBackend) checkTeam(ctx context.Context, c *github.Client, org, slug string) error {
+	if _, resp, err := c.Teams.GetTeamBySlug(ctx, org, slug); err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("team %s/%s not found, check the team slug and that the app has organization members read permission: %w",
+				org, slug, err)
+		}
+		return fmt.Errorf("failed to get team %s/%s: %w", org, slug, err)
+	}
+	return nil
+}
+
+// teamResolver resolves team memberships for policy rules, caching the
+// results of the backend.
+type teamResolver struct {
+	backend teamMembershipBackend
+	// cache is nil when memberships are not cached.
+	cache *cache.Cache[bool]
+}
+
+// newTeamResolver creates a team resolver caching memberships for ttl, or not
+// caching them if ttl is not positive.
+func newTeamResolver(backend teamMembershipBackend, ttl time.Duration) *teamResolver {
+	r := &teamResolver{backend: backend}
+	if ttl > 0 {
+		r.cache = cache.New[bool](ttl)
+	}
+	return r
+}
+
+// IsMember reports whether the user is an active member of the team, in the
+// "org/team-slug" format.
+func (r *teamResolver) IsMember(ctx context.Context, team, login string) (bool, error) {
+	org, slug, ok := strings.Cut(team, "/")
+	if !ok || org == "" || slug == "" {
+		return false, fmt.Errorf("team %q must be in the format org/team-slug", team)
+	}
+
+	// Logins, organizations and team slugs are case insensitive.
+	key := strings.ToLower(team + "/" + login)
+	if r.cache != nil {
+		if member, ok := r.cache.Lookup(key); ok {
+			return member, nil
+		}
+	}
+
+	member, err := r.backend.IsTeamMember(ctx, org, slug, login)
+	if err != nil {
+		return false, err //nolint:wrapcheck // Want passthrough
+	}
+	if r.cache != nil {
+		r.cache.Set(key, member)
+	}
+	return member, nil
+}
+
+// MemberOfAny returns the first of the teams the user is an active member of,
+// or an empty string if none.
+func (r *teamResolver) MemberOfAny(ctx context.Context, teams []string, login string) (string, error) {
+	for _, team := range teams {
+		member, err := r.IsMember(ctx, team, login)
+		if err != nil {
+			return "", err
+		}
+		if member {
+			return team, nil
+		}
+	}
+	return "", nil
+}
+
+// validateIssueAuthorTeams verifies the issue author is a member of the
+// required teams, if any, and records it.
+func (v *Validator) validateIssueAuthorTeams(ctx context.Context, pi *pluginGitHubIssue, issue *github.Issue) error {
+	teams := v.policy.IssueAuthorTeams
+	if len(teams) == 0 {
+		return nil
+	}
+	author := issue.GetUser().GetLogin()
+	team, err := v.teams.MemberOfAny(ctx, teams, author)
+	if err != nil {
+		return fmt.Errorf("failed to check issue author team membership: %w", err)
+	}
+	if team == "" {
+		return violationf(ruleIssueAuthorTeams, "issue author %s is not a member of %q", author, teams)
+	}
+	pi.Author = author
+	return nil
+}
+
+// validateIssueAssigneeTeams verifies an issue assignee is a member of the
+// required teams, if any, and records the first such assignee.
+func (v *Validator) validateIssueAssigneeTeams(ctx context.Context, pi *pluginGitHubIssue, issue *github.Issue) error {
+	teams := v.policy.IssueAssigneeTeams
+	if len(teams) == 0 {
+		return nil
+	}
+	for _, assignee := range issue.Assignees {
+		team, err := v.teams.MemberOfAny(ctx, teams, assignee.GetLogin())
+		if err != nil {
+			return fmt.Errorf("failed to check issue assignee team membership: %w", err)
+		}
+		if team != "" {
+			pi.Assignee = assignee.GetLogin()
+			return nil
+		}
+	}
+	return violationf(ruleIssueAssigneeTeams, "issue has no assignee who is a member of %q", teams)
+}
@@ -0,0 +1,264 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/abcxyz/pkg/testutil"
+)
+
+// testTeamBackend is a fake org/team backend, with the members of each team
+// keyed by "org/team-slug".
+type testTeamBackend struct {
+	teams map[string][]string
+	err   error
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (b *testTeamBackend) IsTeamMember(ctx context.Context, org, slug, login string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.calls++
+
+	if b.err != nil {
+		return false, b.err
+	}
+	for _, m := range b.teams[org+"/"+slug] {
+		if strings.EqualFold(m, login) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestTeamResolver(t *testing.T) {
+	t.Parallel()
+
+	backend := &testTeamBackend{
+		teams: map[string][]string{
+			"my-org/sre":    {"alice"},
+			"my-org/oncall": {"bob"},
+		},
+	}
+	r := newTeamResolver(backend, time.Minute)
+	t.Cleanup(r.cache.Stop)
+
+	ctx := t.Context()
+	for range 2 {
+		team, err := r.MemberOfAny(ctx, []string{"my-org/sre", "my-org/oncall"}, "Bob")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := team, "my-org/oncall"; got != want {
+			t.Errorf("MemberOfAny() got %q, want %q", got, want)
+		}
+	}
+	// The second lookups are served from the cache.
+	if got, want := backend.calls, 2; got != want {
+		t.Errorf("backend calls got %d, want %d", got, want)
+	}
+
+	team, err := r.MemberOfAny(ctx, []string{"my-org/sre"}, "mallory")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if team != "" {
+		t.Errorf("MemberOfAny() got %q, want no team", team)
+	}
+
+	if _, err := r.IsMember(ctx, "sre", "alice"); err == nil {
+		t.Error("IsMember() with invalid team got no error")
+	}
+}
+
+func TestTeamResolver_NoCache(t *testing.T) {
+	t.Parallel()
+
+	backend := &testTeamBackend{teams: map[string][]string{"my-org/sre": {"alice"}}}
+	r := newTeamResolver(backend, 0)
+
+	for range 2 {
+		if _, err := r.IsMember(t.Context(), "my-org/sre", "alice"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := backend.calls, 2; got != want {
+		t.Errorf("backend calls got %d, want %d", got, want)
+	}
+}
+
+func TestGitHubTeamBackend(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		slug       string
+		login      string
+		wantMember bool
+		wantErr    string
+	}{
+		{
+			name:       "active_member",
+			slug:       "sre",
+			login:      "alice",
+			wantMember: true,
+		},
+		{
+			name:  "pending_member",
+			slug:  "sre",
+			login: "pending-user",
+		},
+		{
+			name:  "not_member",
+			slug:  "sre",
+			login: "mallory",
+		},
+		{
+			name:    "team_not_found",
+			slug:    "sre-typo",
+			login:   "alice",
+			wantErr: "team my-org/sre-typo not found, check the team slug and that the app has organization members read permission",
+		},
+		{
+			name:    "team_lookup_error",
+			slug:    "broken",
+			login:   "alice",
+			wantErr: "failed to get team my-org/broken",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			installation := testGitHubInstallation(t, http.StatusCreated)
+			hc := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/orgs/my-org/teams/sre":
+					fmt.Fprint(w, `{"slug": "sre"}`)
+				case "/orgs/my-org/teams/sre/memberships/alice":
+					fmt.Fprint(w, `{"state": "active"}`)
+				case "/orgs/my-org/teams/sre/memberships/pending-user":
+					fmt.Fprint(w, `{"state": "pending"}`)
+				case "/orgs/my-org/teams/broken":
+					http.Error(w, "injected server error", http.StatusInternalServerError)
+				default:
+					http.Error(w, "not found", http.StatusNotFound)
+				}
+			})
+
+			b := &gitHubTeamBackend{client: github.NewClient(hc), githubInstallation: installation}
+			got, err := b.IsTeamMember(t.Context(), "my-org", tc.slug, tc.login)
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if got != tc.wantMember {
+				t.Errorf("IsTeamMember() got %t, want %t", got, tc.wantMember)
+			}
+		})
+	}
+}
+
+func TestMatchIssue_Teams(t *testing.T) {
+	t.Parallel()
+
+	teams := map[string][]string{
+		"my-org/oncall": {"alice"},
+		"my-org/sre":    {"bob"},
+	}
+
+	issue := func(author string, assignees ...string) []byte {
+		var as []string
+		for _, a := range assignees {
+			as = append(as, fmt.Sprintf(`{"login": %q}`, a))
+		}
+		return []byte(fmt.Sprintf(`{"state": "open", "user": {"login": %q}, "assignees": [%s]}`, author, strings.Join(as, ",")))
+	}
+
+	cases := []struct {
+		name         string
+		policy       *Policy
+		issue        []byte
+		backendErr   error
+		wantAuthor   string
+		wantAssignee string
+		wantErr      string
+		wantInvalid  bool
+	}{
+		{
+			name:         "author_and_assignee",
+			policy:       &Policy{IssueAuthorTeams: []string{"my-org/oncall"}, IssueAssigneeTeams: []string{"my-org/sre"}},
+			issue:        issue("alice", "carol", "bob"),
+			wantAuthor:   "alice",
+			wantAssignee: "bob",
+		},
+		{
+			name:        "author_not_member",
+			policy:      &Policy{IssueAuthorTeams: []string{"my-org/oncall"}},
+			issue:       issue("mallory"),
+			wantErr:     `issue author mallory is not a member of ["my-org/oncall"]`,
+			wantInvalid: true,
+		},
+		{
+			name:        "no_assignee_member",
+			policy:      &Policy{IssueAssigneeTeams: []string{"my-org/sre"}},
+			issue:       issue("alice", "carol"),
+			wantErr:     `issue has no assignee who is a member of ["my-org/sre"]`,
+			wantInvalid: true,
+		},
+		{
+			name:       "backend_error",
+			policy:     &Policy{IssueAuthorTeams: []string{"my-org/oncall"}},
+			issue:      issue("alice"),
+			backendErr: fmt.Errorf("injected error"),
+			wantErr:    "failed to check issue author team membership: injected error",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			installation := testGitHubInstallation(t, http.StatusCreated)
+			hc := newTestServer(t, testHandleIssueReturn(t, tc.issue))
+
+			v := NewValidator(github.NewClient(hc), installation, tc.policy)
+			v.teams = newTeamResolver(&testTeamBackend{teams: teams, err: tc.backendErr}, 0)
+
+			got, err := v.MatchIssue(t.Context(), testGitHubIssueURL)
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if got, want := errors.Is(err, errInvalidJustification), tc.wantInvalid; got != want {
+				t.Errorf("errors.Is(%v, errInvalidJustification) = %t, want %t", err, got, want)
+			}
+			if got.Author != tc.wantAuthor || got.Assignee != tc.wantAssignee {
+				t.Errorf("got author %q and assignee %q, want %q and %q", got.Author, got.Assignee, tc.wantAuthor, tc.wantAssignee)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"text/template"
+
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/metadata"
+
+	jvspb "github.com/abcxyz/jvs/apis/v0"
+)
+
+const (
+	// defaultExampleURL is the example justification used in hints.
+	defaultExampleURL = "https://github.com/my-org/my-repo/issues/1"
+
+	// acceptLanguageMetadataKey is the gRPC metadata key of the languages
+	// accepted by the UI, in the Accept-Language header format. It must be
+	// forwarded by the caller, which JVS doesn't do yet.
+	acceptLanguageMetadataKey = "accept-language"
+)
+
+// LocalizedText is the display name and hint of a category in a language.
+// Both are literal text, or templates rendered with uiTemplateData if UI
+// templates are enabled.
+//
+// Localized variants are only served to callers sending the "accept-language"
+// gRPC metadata, which JVS doesn't do, so they are currently unavailable with
+// JVS.
+type LocalizedText struct {
+	DisplayName string `yaml:"display_name"`
+	Hint        string `yaml:"hint"`
+}
+
+// uiTemplateData is the data display name and hint templates are rendered
+// with, e.g. "Paste an issue URL like {{.ExampleURL}}" or
+// "Issues must be of type {{join .Policy.IssueTypes}}".
+type uiTemplateData struct {
+	Category   string
+	ExampleURL string
+	Policy     *Policy
+}
+
+// uiTemplateFuncs are the functions available in display name and hint
+// templates.
+var uiTemplateFuncs = template.FuncMap{
+	// join joins a list with commas, e.g. allowed types or required labels.
+	"join": func(s []string) string { return strings.Join(s, ", ") },
+}
+
+// renderUIText renders the display name and hint templates, or uses them as
+// literal text if templates is false.
+func renderUIText(text *LocalizedText, data *uiTemplateData, templates bool) (*jvspb.UIData, error) {
+	if !templates {
+		return &jvspb.UIData{DisplayName: text.DisplayName, Hint: text.Hint}, nil
+	}
+	displayName, err := renderUITemplate("display_name", text.DisplayName, data)
+	if err != nil {
+		return nil, err
+	}
+	hint, err := renderUITemplate("hint", text.Hint, data)
+	if err != nil {
+		return nil, err
+	}
+	return &jvspb.UIData{DisplayName: displayName, Hint: hint}, nil
+}
+
+// renderUITemplate renders a single template.
+func renderUITemplate(name, text string, data *uiTemplateData) (string, error) {
+	tmpl, err := template.New(name).Funcs(uiTemplateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %w", name, err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+	return b.String(), nil
+}
+
+// newPluginCategory creates a category validated by validator, rendering its
+// UI data in the default and localized variants, keyed by BCP 47 language tag,
+// as templates if templates is true.
+func newPluginCategory(validator referenceMatcher, data *uiTemplateData, text *LocalizedText, localized map[string]*LocalizedText, templates bool) (*pluginCategory, error) {
+	if data.ExampleURL == "" {
+		data.ExampleURL = defaultExampleURL
+	}
+
+	uiData, err := renderUIText(text, data, templates)
+	if err != nil {
+		return nil, err
+	}
+	c := &pluginCategory{
+		validator: validator,
+		uiData:    uiData,
+	}
+	if len(localized) == 0 {
+		return c, nil
+	}
+
+	// The first tag is the fallback of the matcher.
+	tags := []language.Tag{language.Und}
+	for _, lang := range slices.Sorted(maps.Keys(localized)) {
+		tag, err := language.Parse(lang)
+		if err != nil {
+			return nil, fmt.Errorf("invalid language %q: %w", lang, err)
+		}
+		// Localized variants default to the default display name and hint.
+		merged := *localized[lang]
+		if merged.DisplayName == "" {
+			merged.DisplayName = text.DisplayName
+		}
+		if merged.Hint == "" {
+			merged.Hint = text.Hint
+		}
+		d, err := renderUIText(&merged, data, templates)
+		if err != nil {
+			return nil, fmt.Errorf("language %q: %w", lang, err)
+		}
+		tags = append(tags, tag)
+		c.localized = append(c.localized, d)
+	}
+	c.matcher = language.NewMatcher(tags)
+	return c, nil
+}
+
+// uiDataFor returns the UI data in the language best matching the languages
+// accepted by the request, or the default UI data.
+func (c *pluginCategory) uiDataFor(ctx context.Context) *jvspb.UIData {
+	if c.matcher == nil {
+		return c.uiData
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	accept := md.Get(acceptLanguageMetadataKey)
+	if len(accept) == 0 {
+		return c.uiData
+	}
+	tags, _, err := language.ParseAcceptLanguage(strings.Join(accept, ","))
+	if err != nil || len(tags) == 0 {
+		return c.uiData
+	}
+	if _, i, confidence := c.matcher.Match(tags...); i > 0 && confidence != language.No {
+		return c.localized[i-1]
+	}
+	return c.uiData
+}
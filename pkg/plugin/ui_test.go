@@ -0,0 +1,244 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+
+	jvspb "github.com/abcxyz/jvs/apis/v0"
+	"github.com/abcxyz/pkg/testutil"
+)
+
+func TestNewPluginCategory(t *testing.T) {
+	t.Parallel()
+
+	data := &uiTemplateData{
+		Category: "github-incident",
+		Policy:   &Policy{IssueTypes: []string{"Incident", "Change"}},
+	}
+
+	cases := []struct {
+		name      string
+		text      *LocalizedText
+		localized map[string]*LocalizedText
+		templates bool
+		want      *jvspb.UIData
+		wantErr   string
+	}{
+		{
+			name: "static",
+			text: &LocalizedText{DisplayName: "GitHub", Hint: "Issue URL"},
+			want: &jvspb.UIData{DisplayName: "GitHub", Hint: "Issue URL"},
+		},
+		{
+			name: "literal",
+			text: &LocalizedText{DisplayName: "GitHub ({{.Category}})", Hint: "Issue URL, not {{ a template"},
+			want: &jvspb.UIData{DisplayName: "GitHub ({{.Category}})", Hint: "Issue URL, not {{ a template"},
+		},
+		{
+			name:      "template",
+			templates: true,
+			text: &LocalizedText{
+				DisplayName: "GitHub ({{.Category}})",
+				Hint:        "{{join .Policy.IssueTypes}} issue, like {{.ExampleURL}}",
+			},
+			want: &jvspb.UIData{
+				DisplayName: "GitHub (github-incident)",
+				Hint:        "Incident, Change issue, like https://github.com/my-org/my-repo/issues/1",
+			},
+		},
+		{
+			name:      "invalid_template",
+			templates: true,
+			text:      &LocalizedText{DisplayName: "GitHub", Hint: "{{.Policy"},
+			wantErr:   "failed to parse hint template",
+		},
+		{
+			name:      "unknown_field",
+			templates: true,
+			text:      &LocalizedText{DisplayName: "{{.Owner}}", Hint: "Issue URL"},
+			wantErr:   "failed to render display_name template",
+		},
+		{
+			name:      "invalid_language",
+			text:      &LocalizedText{DisplayName: "GitHub", Hint: "Issue URL"},
+			localized: map[string]*LocalizedText{"not a language": {Hint: "?"}},
+			wantErr:   `invalid language "not a language"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			data := *data
+			got, err := newPluginCategory(nil, &data, tc.text, tc.localized, tc.templates)
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got.uiData, cmpopts.IgnoreUnexported(jvspb.UIData{})); diff != "" {
+				t.Errorf("uiData unexpected diff (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPluginCategory_UIDataFor(t *testing.T) {
+	t.Parallel()
+
+	c, err := newPluginCategory(nil,
+		&uiTemplateData{Category: githubCategory, Policy: &Policy{}},
+		&LocalizedText{DisplayName: "GitHub", Hint: "Issue URL, like {{.ExampleURL}}"},
+		map[string]*LocalizedText{
+			"de":    {Hint: "Issue-URL, z.B. {{.ExampleURL}}"},
+			"pt-BR": {DisplayName: "GitHub (BR)", Hint: "URL da issue"},
+		}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name           string
+		acceptLanguage string
+		want           *jvspb.UIData
+	}{
+		{
+			name: "no_metadata",
+			want: &jvspb.UIData{DisplayName: "GitHub", Hint: "Issue URL, like https://github.com/my-org/my-repo/issues/1"},
+		},
+		{
+			name:           "localized",
+			acceptLanguage: "de-CH, en;q=0.8",
+			want:           &jvspb.UIData{DisplayName: "GitHub", Hint: "Issue-URL, z.B. https://github.com/my-org/my-repo/issues/1"},
+		},
+		{
+			name:           "region",
+			acceptLanguage: "pt-BR",
+			want:           &jvspb.UIData{DisplayName: "GitHub (BR)", Hint: "URL da issue"},
+		},
+		{
+			name:           "unsupported",
+			acceptLanguage: "ja",
+			want:           &jvspb.UIData{DisplayName: "GitHub", Hint: "Issue URL, like https://github.com/my-org/my-repo/issues/1"},
+		},
+		{
+			name:           "invalid",
+			acceptLanguage: ";;;",
+			want:           &jvspb.UIData{DisplayName: "GitHub", Hint: "Issue URL, like https://github.com/my-org/my-repo/issues/1"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			if tc.acceptLanguage != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(acceptLanguageMetadataKey, tc.acceptLanguage))
+			}
+			if diff := cmp.Diff(tc.want, c.uiDataFor(ctx), cmpopts.IgnoreUnexported(jvspb.UIData{})); diff != "" {
+				t.Errorf("uiDataFor() unexpected diff (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestGetUIData_PluginClient calls GetUIData through the JVS plugin client over
+// gRPC, like JVS does. JVS currently calls it with the context of the UI
+// request, without the "accept-language" metadata, so the default UI data is
+// returned until JVS forwards the Accept-Language header.
+func TestGetUIData_PluginClient(t *testing.T) {
+	t.Parallel()
+
+	c, err := newPluginCategory(nil,
+		&uiTemplateData{Category: githubCategory, Policy: &Policy{}},
+		&LocalizedText{DisplayName: "GitHub", Hint: "Issue URL"},
+		map[string]*LocalizedText{"de": {Hint: "Issue-URL"}}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &GitHubPlugin{
+		categories: map[string]*pluginCategory{githubCategory: c},
+		category:   githubCategory,
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	jvspb.RegisterJVSPluginServer(s, &jvspb.PluginServer{Impl: p})
+	go s.Serve(lis) //nolint:errcheck // Stopped on cleanup
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	raw, err := (&jvspb.ValidatorPlugin{}).GRPCClient(t.Context(), nil, conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, ok := raw.(*jvspb.PluginClient)
+	if !ok {
+		t.Fatalf("GRPCClient() got %T, want *jvspb.PluginClient", raw)
+	}
+
+	cases := []struct {
+		name           string
+		acceptLanguage string
+		want           *jvspb.UIData
+	}{
+		{
+			name: "jvs_request",
+			want: &jvspb.UIData{DisplayName: "GitHub", Hint: "Issue URL"},
+		},
+		{
+			name:           "forwarded_accept_language",
+			acceptLanguage: "de",
+			want:           &jvspb.UIData{DisplayName: "GitHub", Hint: "Issue-URL"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			if tc.acceptLanguage != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, acceptLanguageMetadataKey, tc.acceptLanguage)
+			}
+			got, err := client.GetUIData(ctx, &jvspb.GetUIDataRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreUnexported(jvspb.UIData{})); diff != "" {
+				t.Errorf("GetUIData() unexpected diff (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -22,9 +22,11 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/go-github/v55/github"
 
+	"github.com/abcxyz/pkg/cache"
 	"github.com/abcxyz/pkg/githubauth"
 )
 
@@ -36,6 +38,16 @@ const (
 type Validator struct {
 	client             *github.Client
 	githubInstallation *githubauth.AppInstallation
+	policy             *Policy
+	teams              *teamResolver
+	// repositories caches repository metadata by lowercase "owner/repo", it
+	// is nil when repository metadata is not cached.
+	repositories *cache.Cache[*pluginGitHubRepository]
+	// suggestions caches the issue suggestions by login, owners and query.
+	suggestions *cache.Cache[[]*Suggestion]
+	// usedLabel is the label added to issues by the write-back, which is left
+	// out of issue snapshots.
+	usedLabel string
 }
 
 // ExchangeResponse is the GitHub API response of requesting an access token
@@ -50,49 +62,182 @@ type pluginGitHubIssue struct {
 	Owner       string
 	RepoName    string
 	IssueNumber int
+
+	// SnapshotHash is the hash of the issue content at validation time, see
+	// issueSnapshotHash.
+	SnapshotHash string
+
+	// Project is the project (v2) the issue is required to be in, in the
+	// "owner/number" format, and ProjectStatus the value of its status field.
+	// Both are empty when no project is required.
+	Project       string
+	ProjectStatus string
+
+	// LinkedPullRequests are the URLs of the open or merged pull requests
+	// linked to the issue. It is only set when linked pull requests are
+	// required.
+	LinkedPullRequests []string
+
+	// Milestone is the title of the issue milestone, if any, and Type the
+	// issue type. Type is only set when the issue type is restricted.
+	Milestone string
+	Type      string
+
+	// Author is the login of the issue author, and Assignee the login of the
+	// first assignee who is a member of one of the required teams. Each is
+	// only set when the team membership is required.
+	Author   string
+	Assignee string
+
+	// Approver is the login of the team member who approved the issue, and
+	// ApprovedAt the time of the approval. Both are only set when approval is
+	// required.
+	Approver   string
+	ApprovedAt time.Time
+}
+
+// URL returns the canonical URL of the issue.
+func (pi *pluginGitHubIssue) URL() string {
+	return fmt.Sprintf("https://github.com/%s/%s/issues/%d", pi.Owner, pi.RepoName, pi.IssueNumber)
 }
 
-// NewValidator creates a validator.
-func NewValidator(ghClinet *github.Client, ghInstall *githubauth.AppInstallation) *Validator {
-	return &Validator{
+// NewValidator creates a validator enforcing the given policy.
+func NewValidator(ghClinet *github.Client, ghInstall *githubauth.AppInstallation, policy *Policy) *Validator {
+	v := &Validator{
 		client:             ghClinet,
 		githubInstallation: ghInstall,
+		policy:             policy,
+		teams: newTeamResolver(&gitHubTeamBackend{
+			client:             ghClinet,
+			githubInstallation: ghInstall,
+		}, policy.TeamCacheTTL),
+		suggestions: cache.New[[]*Suggestion](suggestionCacheTTL),
+	}
+	if policy.RepositoryCacheTTL > 0 {
+		v.repositories = cache.New[*pluginGitHubRepository](policy.RepositoryCacheTTL)
 	}
+	return v
+}
+
+// SetUsedLabel sets the label added to issues by the write-back, so adding it
+// doesn't change the issue snapshots.
+func (v *Validator) SetUsedLabel(label string) {
+	v.usedLabel = label
 }
 
 // MatchIssue parses issue info from provided issueURL and validate if the issue is valid.
 func (v *Validator) MatchIssue(ctx context.Context, issueURL string) (*pluginGitHubIssue, error) {
 	info, err := parseIssueInfoFromURL(issueURL)
 	if err != nil {
-		return nil, fmt.Errorf("%w: failed to parse issueURL: %w", errInvalidJustification, err)
+		return nil, invalidf(ReasonReferenceMalformed, "failed to parse issueURL: %w", err)
+	}
+
+	var project *projectRef
+	if v.policy.Project != "" {
+		project, err = parseProjectRef(v.policy.Project)
+		if err != nil {
+			return nil, fmt.Errorf("invalid project policy: %w", err)
+		}
 	}
+	return v.matchIssue(ctx, info, project)
+}
 
+// matchIssue validates the issue, and if project is not nil, that the issue is
+// in the project, and its milestone, type, body, labels, team memberships,
+// linked pull requests and approval if required. Each check returns its policy
+// violation, which is enforced here, and the checks of the rules which are off
+// are skipped.
+func (v *Validator) matchIssue(ctx context.Context, info *pluginGitHubIssue, project *projectRef) (*pluginGitHubIssue, error) {
 	t, err := v.getAccessToken(ctx, info.RepoName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get access token: %w", err)
 	}
 	c := v.client.WithAuthToken(t)
 
-	return info, validateIssue(ctx, c, info)
+	issue, err := validateIssue(ctx, c, info)
+	if err != nil {
+		return info, err
+	}
+
+	hash, err := issueSnapshotHash(issue, v.usedLabel)
+	if err != nil {
+		return info, fmt.Errorf("failed to compute issue snapshot hash: %w", err)
+	}
+	info.SnapshotHash = hash
+	info.Milestone = issue.GetMilestone().GetTitle()
+
+	// The project check covers two reasons, so it is only skipped if both are
+	// off.
+	if project != nil && !(v.ruleOff(ReasonProjectNotAllowed, "") && v.ruleOff(ReasonProjectStatusNotAllowed, "")) {
+		if err := v.enforce(ctx, v.validateProjectItem(ctx, info, project)); err != nil {
+			return info, err
+		}
+	}
+
+	return info, v.runIssueChecks(ctx, []*issueCheck{
+		{ReasonMilestoneNotAllowed, ruleIssueMilestones, func() error { return v.validateIssueMilestones(issue) }},
+		{ReasonMilestoneNotAllowed, ruleIssueRequireOpenMilestone, func() error { return v.validateIssueOpenMilestone(issue) }},
+		{ReasonIssueTypeNotAllowed, "", func() error { return v.validateIssueType(ctx, c, info) }},
+		{ReasonIssueBodyInvalid, ruleIssueRequiredSections, func() error { return v.validateIssueSections(issue.GetBody()) }},
+		{ReasonIssueBodyInvalid, ruleIssueBodyPatterns, func() error { return v.validateIssueBodyPatterns(issue.GetBody()) }},
+		{ReasonLabelMissing, "", func() error { return v.validateIssuePropertyLabels(ctx, info, issue) }},
+		{ReasonTeamNotAllowed, ruleIssueAuthorTeams, func() error { return v.validateIssueAuthorTeams(ctx, info, issue) }},
+		{ReasonTeamNotAllowed, ruleIssueAssigneeTeams, func() error { return v.validateIssueAssigneeTeams(ctx, info, issue) }},
+		{ReasonLinkMissing, ruleIssueRequireLinkedPR, func() error {
+			if !v.policy.IssueRequireLinkedPullRequest {
+				return nil
+			}
+			return v.validateLinkedPullRequests(ctx, info)
+		}},
+		{ReasonApprovalMissing, "", func() error {
+			if len(v.policy.ApprovalTeams) == 0 {
+				return nil
+			}
+			return v.validateApproval(ctx, c, info, issue.GetUser().GetLogin())
+		}},
+	})
+}
+
+// issueCheck is a check of an issue against the policy rule with the reason.
+// The rule is empty for the reasons covering a single rule.
+type issueCheck struct {
+	reason Reason
+	rule   policyRule
+	check  func() error
+}
+
+// runIssueChecks runs the checks in order, skipping those of the rules which
+// are off, and returns the first violation which is enforced.
+func (v *Validator) runIssueChecks(ctx context.Context, checks []*issueCheck) error {
+	for _, c := range checks {
+		if v.ruleOff(c.reason, c.rule) {
+			continue
+		}
+		if err := v.enforce(ctx, c.check()); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// validateIssue verifies if the issue exists and the issue is open.
-func validateIssue(ctx context.Context, c *github.Client, pi *pluginGitHubIssue) error {
+// validateIssue verifies if the issue exists and the issue is open, and returns
+// the fetched issue.
+func validateIssue(ctx context.Context, c *github.Client, pi *pluginGitHubIssue) (*github.Issue, error) {
 	issue, resp, err := c.Issues.Get(ctx, pi.Owner, pi.RepoName, pi.IssueNumber)
 	if err != nil {
 		// When the issue doesn't not exist, github rest api will return a 404
 		// all other non-200 status code will be treated as internal error.
 		//
 		// See: https://docs.github.com/en/rest/issues/issues?apiVersion=2022-11-28#get-an-issue--status-codes.
-		if resp.StatusCode == http.StatusNotFound {
-			return fmt.Errorf("%w: issue not found: %w", errInvalidJustification, err)
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, invalidf(ReasonReferenceNotFound, "issue not found: %w", err)
 		}
-		return fmt.Errorf("failed to get issue info: %w", err)
+		return nil, fmt.Errorf("failed to get issue info: %w", err)
 	}
 	if s := issue.GetState(); s != "open" {
-		return fmt.Errorf("%w: issue is in state: %s, please make sure to use an open issue", errInvalidJustification, s)
+		return nil, invalidf(ReasonIssueClosed, "issue is in state: %s, please make sure to use an open issue", s)
 	}
-	return nil
+	return issue, nil
 }
 
 // getAccessToken gets an access token with issue read permission to the repo
@@ -115,7 +260,7 @@ func (v *Validator) getAccessToken(ctx context.Context, repoName string) (string
 // parseIssueInfoFromURL parses pluginGitHubIssue from Issue URL.
 func parseIssueInfoFromURL(issueURL string) (*pluginGitHubIssue, error) {
 	if match, _ := regexp.MatchString(issueURLPatternRegExp, issueURL); !match {
-		return nil, fmt.Errorf("invalid issue url, issueURL doesn't match pattern: %s", issueURLPatternRegExp)
+		return nil, fmt.Errorf("invalid issue url, %s", referenceHint(issueURL, referenceKindIssue))
 	}
 	u, err := url.Parse(issueURL)
 	if err != nil {
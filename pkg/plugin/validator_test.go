@@ -15,6 +15,7 @@
 package plugin
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
@@ -52,6 +53,7 @@ func TestMatchIssue(t *testing.T) {
 		wantPluginGitHubIssue   *pluginGitHubIssue
 		// check is returned error is the correct type
 		isInvalidJustificationErr bool
+		wantReason                Reason
 	}{
 		{
 			name:                    "success",
@@ -59,9 +61,10 @@ func TestMatchIssue(t *testing.T) {
 			fakeTokenServerResqCode: http.StatusCreated,
 			issueBytes:              []byte(`{"state": "open"}`),
 			wantPluginGitHubIssue: &pluginGitHubIssue{
-				Owner:       testIssueOwner,
-				RepoName:    testIssueRepoName,
-				IssueNumber: testExistIssueNumber,
+				Owner:        testIssueOwner,
+				RepoName:     testIssueRepoName,
+				IssueNumber:  testExistIssueNumber,
+				SnapshotHash: testOpenIssueSnapshotHash,
 			},
 		},
 		{
@@ -70,14 +73,16 @@ func TestMatchIssue(t *testing.T) {
 			fakeTokenServerResqCode:   http.StatusCreated,
 			wantErrSubstr:             "invalid issue url",
 			isInvalidJustificationErr: true,
+			wantReason:                ReasonReferenceMalformed,
 			issueBytes:                []byte(`{"state": "open"}`),
 		},
 		{
 			name:                      "issue_not_int",
 			issueURL:                  fmt.Sprintf("%s/%s/%s/issues/%s", issueURLHost, testIssueOwner, testIssueRepoName, "abc"),
 			fakeTokenServerResqCode:   http.StatusCreated,
-			wantErrSubstr:             "invalid issue url, issueURL doesn't match pattern",
+			wantErrSubstr:             "invalid issue url, expected a URL like https://github.com/owner/repo/issues/1",
 			isInvalidJustificationErr: true,
+			wantReason:                ReasonReferenceMalformed,
 			issueBytes:                []byte(`{"state": "open"}`),
 		},
 		{
@@ -94,6 +99,7 @@ func TestMatchIssue(t *testing.T) {
 			fakeTokenServerResqCode:   http.StatusCreated,
 			wantErrSubstr:             "issue is in state: closed",
 			isInvalidJustificationErr: true,
+			wantReason:                ReasonIssueClosed,
 			issueBytes:                []byte(`{"state": "closed"}`),
 			wantPluginGitHubIssue: &pluginGitHubIssue{
 				Owner:       testIssueOwner,
@@ -107,6 +113,7 @@ func TestMatchIssue(t *testing.T) {
 			fakeTokenServerResqCode:   http.StatusCreated,
 			wantErrSubstr:             "issue not found",
 			isInvalidJustificationErr: true,
+			wantReason:                ReasonReferenceNotFound,
 			issueBytes:                []byte(`{"state": "closed"}`),
 			wantPluginGitHubIssue: &pluginGitHubIssue{
 				Owner:       testIssueOwner,
@@ -122,38 +129,12 @@ func TestMatchIssue(t *testing.T) {
 
 			ctx := t.Context()
 
-			fakeGitHub := func() *httptest.Server {
-				mux := http.NewServeMux()
-				mux.Handle("GET /app/installations/123", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-					fmt.Fprintf(w, `{"access_tokens_url": "http://%s/app/installations/123/access_tokens"}`, r.Host)
-				}))
-				mux.Handle("POST /app/installations/123/access_tokens", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-					w.WriteHeader(tc.fakeTokenServerResqCode)
-					fmt.Fprintf(w, `{"token": "this-is-the-token-from-github"}`)
-				}))
-				return httptest.NewServer(mux)
-			}()
-			t.Cleanup(func() {
-				fakeGitHub.Close()
-			})
-
-			_, testPrivateKey := keyutil.TestGenerateRSAPrivateKey(t)
+			installation := testGitHubInstallation(t, tc.fakeTokenServerResqCode)
 
 			hc := newTestServer(t, testHandleIssueReturn(t, tc.issueBytes))
 			testGitHubClient := github.NewClient(hc)
 
-			testGitHubApp, err := githubauth.NewApp("my-app", testPrivateKey,
-				githubauth.WithBaseURL(fakeGitHub.URL))
-			if err != nil {
-				t.Fatal(err)
-			}
-
-			installation, err := testGitHubApp.InstallationForID(ctx, "123")
-			if err != nil {
-				t.Fatal(err)
-			}
-
-			validator := NewValidator(testGitHubClient, installation)
+			validator := NewValidator(testGitHubClient, installation, &Policy{})
 			gotPluginGitHubIssue, gotErr := validator.MatchIssue(ctx, tc.issueURL)
 			if diff := testutil.DiffErrString(gotErr, tc.wantErrSubstr); diff != "" {
 				t.Errorf("Process(%+v) got unexpected error substring: %v", tc.name, diff)
@@ -161,6 +142,9 @@ func TestMatchIssue(t *testing.T) {
 			if diff := cmp.Diff(gotPluginGitHubIssue, tc.wantPluginGitHubIssue); diff != "" {
 				t.Errorf("Process(%+v) got unexpected pluginGitHubIssue diff (-want, +got):\n%s", tc.name, diff)
 			}
+			if got, want := testReason(gotErr), tc.wantReason; got != want {
+				t.Errorf("Process(%+v) got reason %q, want %q", tc.name, got, want)
+			}
 			if tc.wantErrSubstr != "" {
 				if tc.isInvalidJustificationErr {
 					if !errors.Is(gotErr, errInvalidJustification) {
@@ -176,6 +160,43 @@ func TestMatchIssue(t *testing.T) {
 	}
 }
 
+// testGitHubInstallation creates a GitHub App installation backed by a fake
+// GitHub server, which responds to access token requests with the given status
+// code.
+func testGitHubInstallation(tb testing.TB, tokenStatusCode int) *githubauth.AppInstallation {
+	tb.Helper()
+
+	fakeGitHub := func() *httptest.Server {
+		mux := http.NewServeMux()
+		mux.Handle("GET /app/installations/123", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"access_tokens_url": "http://%s/app/installations/123/access_tokens"}`, r.Host)
+		}))
+		mux.Handle("POST /app/installations/123/access_tokens", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tokenStatusCode)
+			fmt.Fprintf(w, `{"token": "this-is-the-token-from-github"}`)
+		}))
+		return httptest.NewServer(mux)
+	}()
+	tb.Cleanup(func() {
+		fakeGitHub.Close()
+	})
+
+	_, testPrivateKey := keyutil.TestGenerateRSAPrivateKey(tb)
+
+	testGitHubApp, err := githubauth.NewApp("my-app", testPrivateKey,
+		githubauth.WithBaseURL(fakeGitHub.URL),
+		githubauth.WithHTTPClient(&http.Client{Transport: TokenStatusTransport(nil)}))
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	installation, err := testGitHubApp.InstallationForID(context.Background(), "123")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return installation
+}
+
 // newTestServer creates a fake http client.
 func newTestServer(t *testing.T, handler func(w http.ResponseWriter, r *http.Request)) *http.Client {
 	t.Helper()
@@ -0,0 +1,175 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/abcxyz/pkg/githubauth"
+)
+
+const (
+	workflowRunURLPatternRegExp = `^https:\/\/github.com\/([a-zA-Z0-9-]*)\/[a-zA-Z0-9-]*\/actions\/runs\/[0-9]+(\/attempts\/[0-9]+|\/job\/[0-9]+)?$`
+
+	// Workflow run statuses. Queued and in progress runs are always accepted,
+	// completed runs only within the completion window.
+	workflowRunStatusQueued     = "queued"
+	workflowRunStatusInProgress = "in_progress"
+	workflowRunStatusCompleted  = "completed"
+)
+
+// pluginGitHubWorkflowRun contains the required attribute parsed from the
+// workflow run URL, and the attributes of the run used in annotations.
+type pluginGitHubWorkflowRun struct {
+	Owner    string
+	RepoName string
+	RunID    int64
+
+	WorkflowName string
+	HeadBranch   string
+	HeadSHA      string
+	Actor        string
+	Status       string
+}
+
+// MatchWorkflowRun parses workflow run info from provided runURL and validates
+// the run against the policy.
+func (v *Validator) MatchWorkflowRun(ctx context.Context, runURL string) (*pluginGitHubWorkflowRun, error) {
+	info, err := parseWorkflowRunInfoFromURL(runURL)
+	if err != nil {
+		return nil, invalidf(ReasonReferenceMalformed, "failed to parse workflow run url: %w", err)
+	}
+
+	t, err := v.getActionsAccessToken(ctx, info.RepoName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+	c := v.client.WithAuthToken(t)
+
+	run, resp, err := c.Actions.GetWorkflowRunByID(ctx, info.Owner, info.RepoName, info.RunID)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return info, invalidf(ReasonReferenceNotFound, "workflow run not found: %w", err)
+		}
+		return info, fmt.Errorf("failed to get workflow run info: %w", err)
+	}
+
+	info.WorkflowName = run.GetName()
+	info.HeadBranch = run.GetHeadBranch()
+	info.HeadSHA = run.GetHeadSHA()
+	info.Status = run.GetStatus()
+	info.Actor = run.GetTriggeringActor().GetLogin()
+	if info.Actor == "" {
+		info.Actor = run.GetActor().GetLogin()
+	}
+
+	switch s := run.GetStatus(); s {
+	case workflowRunStatusQueued, workflowRunStatusInProgress:
+	case workflowRunStatusCompleted:
+		// The run is not updated anymore once completed, so the update time is
+		// the completion time.
+		window := v.policy.WorkflowRunCompletedWindow
+		if window <= 0 {
+			return info, invalidf(ReasonWorkflowRunCompleted, "workflow run is completed, please make sure to use a queued or in progress run")
+		}
+		if time.Since(run.GetUpdatedAt().Time) > window {
+			return info, invalidf(ReasonWorkflowRunCompleted, "workflow run completed more than %s ago", window)
+		}
+	default:
+		return info, invalidf(ReasonWorkflowRunCompleted, "workflow run is in status: %s, please make sure to use a queued or in progress run", s)
+	}
+
+	if allowed := v.policy.WorkflowBranches; len(allowed) > 0 && !slices.Contains(allowed, info.HeadBranch) {
+		if err := v.enforce(ctx, violationf(ruleWorkflowBranches, "workflow run branch %q is not one of %q", info.HeadBranch, allowed)); err != nil {
+			return info, err
+		}
+	}
+	if allowed := v.policy.WorkflowPaths; len(allowed) > 0 {
+		path, err := workflowPath(ctx, c, info, run)
+		if err != nil {
+			return info, err
+		}
+		if !slices.Contains(allowed, path) {
+			if err := v.enforce(ctx, invalidf(ReasonWorkflowNotAllowed, "workflow %q is not one of %q", path, allowed)); err != nil {
+				return info, err
+			}
+		}
+	}
+
+	return info, nil
+}
+
+// workflowPath returns the file path of the workflow the run belongs to. The
+// workflow run itself doesn't include the path in the client library, so the
+// workflow is fetched.
+func workflowPath(ctx context.Context, c *github.Client, info *pluginGitHubWorkflowRun, run *github.WorkflowRun) (string, error) {
+	w, _, err := c.Actions.GetWorkflowByID(ctx, info.Owner, info.RepoName, run.GetWorkflowID())
+	if err != nil {
+		return "", fmt.Errorf("failed to get workflow info: %w", err)
+	}
+	return w.GetPath(), nil
+}
+
+// getActionsAccessToken gets an access token with actions read permission to
+// the repo which contains the workflow run.
+func (v *Validator) getActionsAccessToken(ctx context.Context, repoName string) (string, error) {
+	tr := &githubauth.TokenRequest{
+		Repositories: []string{repoName},
+		Permissions: map[string]string{
+			"actions": "read",
+		},
+	}
+
+	resp, err := v.githubInstallation.AccessToken(ctx, tr)
+	if err != nil {
+		return "", fmt.Errorf("failed to get access token: %w", err)
+	}
+	return resp, nil
+}
+
+// parseWorkflowRunInfoFromURL parses pluginGitHubWorkflowRun from workflow run
+// URL. URLs of a specific attempt or job of the run are accepted as well.
+func parseWorkflowRunInfoFromURL(runURL string) (*pluginGitHubWorkflowRun, error) {
+	if match, _ := regexp.MatchString(workflowRunURLPatternRegExp, runURL); !match {
+		return nil, fmt.Errorf("invalid workflow run url, %s", referenceHint(runURL, referenceKindWorkflowRun))
+	}
+	u, err := url.Parse(runURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse provided workflow run url: %w", err)
+	}
+
+	arr := strings.Split(u.Path, "/")
+	// len(arr) is not checked here as regexp.MatchString already covers this.
+	runID, err := strconv.ParseInt(arr[5], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert runID %s to int: %w", arr[5], err)
+	}
+
+	return &pluginGitHubWorkflowRun{
+		Owner:    arr[1],
+		RepoName: arr[2],
+		RunID:    runID,
+	}, nil
+}
@@ -0,0 +1,198 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v55/github"
+
+	"github.com/abcxyz/pkg/testutil"
+)
+
+const testGitHubWorkflowRunURL = "https://github.com/test-owner/test-repo/actions/runs/123"
+
+// testHandleWorkflowRun returns a fake http func that serves the test workflow
+// run and its workflow.
+func testHandleWorkflowRun(tb testing.TB, run string) func(w http.ResponseWriter, r *http.Request) {
+	tb.Helper()
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case fmt.Sprintf("%s/%s/%s/actions/runs/123", issueRESTAPIPathPrefix, testIssueOwner, testIssueRepoName):
+			fmt.Fprint(w, run)
+		case fmt.Sprintf("%s/%s/%s/actions/workflows/7", issueRESTAPIPathPrefix, testIssueOwner, testIssueRepoName):
+			fmt.Fprint(w, `{"id": 7, "name": "Deploy", "path": ".github/workflows/deploy.yml"}`)
+		case fmt.Sprintf("%s/%s/%s/actions/runs/404", issueRESTAPIPathPrefix, testIssueOwner, testIssueRepoName):
+			http.Error(w, "run not found", http.StatusNotFound)
+		default:
+			http.Error(w, "injected server error", http.StatusInternalServerError)
+		}
+	}
+}
+
+func TestMatchWorkflowRun(t *testing.T) {
+	t.Parallel()
+
+	recent := time.Now().Add(-10 * time.Minute).UTC().Format(time.RFC3339)
+	old := time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)
+
+	workflowRun := func(status, branch, updatedAt string) string {
+		return fmt.Sprintf(`{"id": 123, "name": "Deploy", "workflow_id": 7, "status": %q, "head_branch": %q, "head_sha": "abc123", "updated_at": %q, "actor": {"login": "octocat"}, "triggering_actor": {"login": "hubot"}}`,
+			status, branch, updatedAt)
+	}
+
+	wantInfo := func(status string) *pluginGitHubWorkflowRun {
+		return &pluginGitHubWorkflowRun{
+			Owner:        testIssueOwner,
+			RepoName:     testIssueRepoName,
+			RunID:        123,
+			WorkflowName: "Deploy",
+			HeadBranch:   "main",
+			HeadSHA:      "abc123",
+			Actor:        "hubot",
+			Status:       status,
+		}
+	}
+
+	cases := []struct {
+		name       string
+		runURL     string
+		policy     *Policy
+		run        string
+		want       *pluginGitHubWorkflowRun
+		wantErr    string
+		wantReason Reason
+	}{
+		{
+			name:   "in_progress",
+			runURL: testGitHubWorkflowRunURL,
+			policy: &Policy{},
+			run:    workflowRun("in_progress", "main", recent),
+			want:   wantInfo("in_progress"),
+		},
+		{
+			name:   "queued_job_url",
+			runURL: testGitHubWorkflowRunURL + "/job/456",
+			policy: &Policy{},
+			run:    workflowRun("queued", "main", recent),
+			want:   wantInfo("queued"),
+		},
+		{
+			name:       "invalid_url",
+			runURL:     "https://github.com/test-owner/test-repo/actions/runs/abc",
+			policy:     &Policy{},
+			wantErr:    "invalid workflow run url",
+			wantReason: ReasonReferenceMalformed,
+		},
+		{
+			name:   "not_found",
+			runURL: "https://github.com/test-owner/test-repo/actions/runs/404",
+			policy: &Policy{},
+			want: &pluginGitHubWorkflowRun{
+				Owner:    testIssueOwner,
+				RepoName: testIssueRepoName,
+				RunID:    404,
+			},
+			wantErr:    "workflow run not found",
+			wantReason: ReasonReferenceNotFound,
+		},
+		{
+			name:       "completed",
+			runURL:     testGitHubWorkflowRunURL,
+			policy:     &Policy{},
+			run:        workflowRun("completed", "main", recent),
+			want:       wantInfo("completed"),
+			wantErr:    "workflow run is completed",
+			wantReason: ReasonWorkflowRunCompleted,
+		},
+		{
+			name:   "completed_within_window",
+			runURL: testGitHubWorkflowRunURL,
+			policy: &Policy{WorkflowRunCompletedWindow: time.Hour},
+			run:    workflowRun("completed", "main", recent),
+			want:   wantInfo("completed"),
+		},
+		{
+			name:       "completed_outside_window",
+			runURL:     testGitHubWorkflowRunURL,
+			policy:     &Policy{WorkflowRunCompletedWindow: time.Hour},
+			run:        workflowRun("completed", "main", old),
+			want:       wantInfo("completed"),
+			wantErr:    "workflow run completed more than 1h0m0s ago",
+			wantReason: ReasonWorkflowRunCompleted,
+		},
+		{
+			name:       "waiting",
+			runURL:     testGitHubWorkflowRunURL,
+			policy:     &Policy{},
+			run:        workflowRun("waiting", "main", recent),
+			want:       wantInfo("waiting"),
+			wantErr:    "workflow run is in status: waiting",
+			wantReason: ReasonWorkflowRunCompleted,
+		},
+		{
+			name:       "branch_not_allowed",
+			runURL:     testGitHubWorkflowRunURL,
+			policy:     &Policy{WorkflowBranches: []string{"release"}},
+			run:        workflowRun("in_progress", "main", recent),
+			want:       wantInfo("in_progress"),
+			wantErr:    `workflow run branch "main" is not one of ["release"]`,
+			wantReason: ReasonBranchNotAllowed,
+		},
+		{
+			name:   "path_allowed",
+			runURL: testGitHubWorkflowRunURL,
+			policy: &Policy{WorkflowPaths: []string{".github/workflows/deploy.yml"}},
+			run:    workflowRun("in_progress", "main", recent),
+			want:   wantInfo("in_progress"),
+		},
+		{
+			name:       "path_not_allowed",
+			runURL:     testGitHubWorkflowRunURL,
+			policy:     &Policy{WorkflowPaths: []string{".github/workflows/release.yml"}},
+			run:        workflowRun("in_progress", "main", recent),
+			want:       wantInfo("in_progress"),
+			wantErr:    `workflow ".github/workflows/deploy.yml" is not one of [".github/workflows/release.yml"]`,
+			wantReason: ReasonWorkflowNotAllowed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			installation := testGitHubInstallation(t, http.StatusCreated)
+			hc := newTestServer(t, testHandleWorkflowRun(t, tc.run))
+
+			v := NewValidator(github.NewClient(hc), installation, tc.policy)
+			got, err := v.MatchWorkflowRun(t.Context(), tc.runURL)
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if got, want := testReason(err), tc.wantReason; got != want {
+				t.Errorf("reason of %v got %q, want %q", err, got, want)
+			}
+			if tc.wantErr == "" || tc.want != nil {
+				if diff := cmp.Diff(tc.want, got); diff != "" {
+					t.Errorf("MatchWorkflowRun() unexpected diff (-want,+got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/abcxyz/pkg/githubauth"
+	"github.com/abcxyz/pkg/logging"
+)
+
+const (
+	// auditCommentMarker identifies the rolling audit comment posted by the
+	// plugin, so it can be found and updated on subsequent validations.
+	auditCommentMarker = "<!-- jvs-plugin-github:audit -->"
+
+	// auditCommentHeader is the fixed part of the audit comment above the
+	// table rows. JVS doesn't pass the authenticated requester to plugins, so
+	// the requester and audience come from the justification annotations set by
+	// the client and are marked as unverified.
+	auditCommentHeader = auditCommentMarker + "\n" +
+		"This issue has been used as a justification for access through JVS.\n" +
+		"The requester and audience are supplied by the client and are not verified.\n\n" +
+		"| Time (UTC) | Requester (unverified, client-supplied) | Audience (unverified, client-supplied) |\n" +
+		"| --- | --- | --- |\n"
+
+	// maxAuditCommentRows bounds the number of rows kept in the audit comment,
+	// the oldest rows are dropped first. It keeps the comment well below the
+	// GitHub comment size limit.
+	maxAuditCommentRows = 200
+
+	// maxAuditCommentSearchPages bounds the number of comment pages searched for
+	// an existing audit comment.
+	maxAuditCommentSearchPages = 10
+
+	// writeBackTimeout bounds how long the asynchronous write-back may take.
+	writeBackTimeout = 30 * time.Second
+)
+
+// auditEntry describes a single access grant recorded on the issue.
+type auditEntry struct {
+	Time      time.Time
+	Requester string
+	Audience  string
+}
+
+// IssueWriter writes back to issues that have been used as justifications.
+// It uses tokens with write permissions that are minted separately from the
+// read-only tokens used for validation.
+type IssueWriter struct {
+	client             *github.Client
+	githubInstallation *githubauth.AppInstallation
+
+	auditComment    bool
+	usedLabel       string
+	usedLabelDryRun bool
+
+	// mu serializes updates of the rolling audit comment within this process,
+	// and guards botLogin.
+	mu sync.Mutex
+
+	// botLogin is the login of the bot user of the GitHub App, which authors
+	// the audit comments. It is looked up on first use.
+	botLogin string
+}
+
+// NewIssueWriter creates an IssueWriter for the write-back modes enabled in
+// the config. It returns nil if no write-back mode is enabled.
+func NewIssueWriter(ghClient *github.Client, ghInstall *githubauth.AppInstallation, cfg *PluginConfig) *IssueWriter {
+	if !cfg.GitHubAuditComment && cfg.GitHubUsedLabel == "" {
+		return nil
+	}
+	return &IssueWriter{
+		client:             ghClient,
+		githubInstallation: ghInstall,
+		auditComment:       cfg.GitHubAuditComment,
+		usedLabel:          cfg.GitHubUsedLabel,
+		usedLabelDryRun:    cfg.GitHubUsedLabelDryRun,
+	}
+}
+
+// WriteBack performs the enabled write-back actions on the issue.
+func (w *IssueWriter) WriteBack(ctx context.Context, info *pluginGitHubIssue, entry *auditEntry) error {
+	logger := logging.FromContext(ctx)
+
+	applyLabel := w.usedLabel != "" && !w.usedLabelDryRun
+	if w.usedLabel != "" && w.usedLabelDryRun {
+		logger.InfoContext(ctx, "dry run, would label issue",
+			"owner", info.Owner,
+			"repo", info.RepoName,
+			"issue_number", info.IssueNumber,
+			"label", w.usedLabel)
+	}
+	if !w.auditComment && !applyLabel {
+		return nil
+	}
+
+	t, err := w.getWriteAccessToken(ctx, info.RepoName)
+	if err != nil {
+		return fmt.Errorf("failed to get access token: %w", err)
+	}
+	c := w.client.WithAuthToken(t)
+
+	var merr error
+	if w.auditComment {
+		if err := w.upsertAuditComment(ctx, c, info, entry); err != nil {
+			merr = errors.Join(merr, fmt.Errorf("failed to post audit comment: %w", err))
+		}
+	}
+	if applyLabel {
+		if err := w.applyUsedLabel(ctx, c, info); err != nil {
+			merr = errors.Join(merr, fmt.Errorf("failed to label issue: %w", err))
+		}
+	}
+	return merr
+}
+
+// applyUsedLabel adds the configured label to the issue, unless the issue
+// already has it.
+func (w *IssueWriter) applyUsedLabel(ctx context.Context, c *github.Client, info *pluginGitHubIssue) error {
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		labels, resp, err := c.Issues.ListLabelsByIssue(ctx, info.Owner, info.RepoName, info.IssueNumber, opts)
+		if err != nil {
+			return fmt.Errorf("failed to list labels: %w", err)
+		}
+		for _, l := range labels {
+			if strings.EqualFold(l.GetName(), w.usedLabel) {
+				return nil
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	if _, _, err := c.Issues.AddLabelsToIssue(ctx, info.Owner, info.RepoName, info.IssueNumber, []string{w.usedLabel}); err != nil {
+		return fmt.Errorf("failed to add label: %w", err)
+	}
+	return nil
+}
+
+// upsertAuditComment appends the entry to the rolling audit comment on the
+// issue, creating the comment if it does not exist yet.
+func (w *IssueWriter) upsertAuditComment(ctx context.Context, c *github.Client, info *pluginGitHubIssue, entry *auditEntry) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	botLogin, err := w.getBotLogin(ctx)
+	if err != nil {
+		return err
+	}
+	existing, err := findAuditComment(ctx, c, info, botLogin)
+	if err != nil {
+		return err
+	}
+
+	if existing == nil {
+		body := renderAuditComment(nil, entry)
+		if _, _, err := c.Issues.CreateComment(ctx, info.Owner, info.RepoName, info.IssueNumber, &github.IssueComment{
+			Body: &body,
+		}); err != nil {
+			return fmt.Errorf("failed to create comment: %w", err)
+		}
+		return nil
+	}
+
+	body := renderAuditComment(parseAuditCommentRows(existing.GetBody()), entry)
+	if _, _, err := c.Issues.EditComment(ctx, info.Owner, info.RepoName, existing.GetID(), &github.IssueComment{
+		Body: &body,
+	}); err != nil {
+		return fmt.Errorf("failed to edit comment: %w", err)
+	}
+	return nil
+}
+
+// getBotLogin returns the login of the bot user of the GitHub App, looking it
+// up with an app token on first use. w.mu must be held.
+func (w *IssueWriter) getBotLogin(ctx context.Context) (string, error) {
+	if w.botLogin != "" {
+		return w.botLogin, nil
+	}
+
+	t, err := w.githubInstallation.App().AppToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to get app token: %w", err)
+	}
+	app, _, err := w.client.WithAuthToken(t).Apps.Get(ctx, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to get github app: %w", err)
+	}
+	if app.GetSlug() == "" {
+		return "", fmt.Errorf("github app has no slug")
+	}
+	w.botLogin = app.GetSlug() + "[bot]"
+	return w.botLogin, nil
+}
+
+// findAuditComment returns the existing audit comment on the issue authored by
+// the bot user, or nil if there is none. Comments by other users are ignored,
+// even with the marker, so audit rows can't be planted by commenters.
+func findAuditComment(ctx context.Context, c *github.Client, info *pluginGitHubIssue, botLogin string) (*github.IssueComment, error) {
+	opts := &github.IssueListCommentsOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for i := 0; i < maxAuditCommentSearchPages; i++ {
+		comments, resp, err := c.Issues.ListComments(ctx, info.Owner, info.RepoName, info.IssueNumber, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list comments: %w", err)
+		}
+		for _, comment := range comments {
+			if strings.EqualFold(comment.GetUser().GetLogin(), botLogin) && strings.HasPrefix(comment.GetBody(), auditCommentMarker) {
+				return comment, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return nil, nil
+}
+
+// parseAuditCommentRows returns the table rows of an existing audit comment.
+func parseAuditCommentRows(body string) []string {
+	rows := make([]string, 0, maxAuditCommentRows)
+	for _, line := range strings.Split(strings.TrimPrefix(body, auditCommentHeader), "\n") {
+		if strings.HasPrefix(line, "| ") && !strings.HasPrefix(line, "| ---") && !strings.HasPrefix(line, "| Time") {
+			rows = append(rows, line)
+		}
+	}
+	return rows
+}
+
+// renderAuditComment renders the audit comment body from the existing rows and
+// the new entry.
+func renderAuditComment(rows []string, entry *auditEntry) string {
+	rows = append(rows, fmt.Sprintf("| %s | %s | %s |",
+		entry.Time.UTC().Format(time.RFC3339),
+		escapeTableCell(entry.Requester),
+		escapeTableCell(entry.Audience)))
+	if len(rows) > maxAuditCommentRows {
+		rows = rows[len(rows)-maxAuditCommentRows:]
+	}
+	return auditCommentHeader + strings.Join(rows, "\n") + "\n"
+}
+
+// escapeTableCell makes s safe to use in a markdown table cell. The requester
+// and audience are supplied by the client, so s is rendered as a code span,
+// where mentions, links and other markdown are not interpreted.
+func escapeTableCell(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	s = strings.ReplaceAll(s, "`", "'")
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r", " ")
+	s = strings.ReplaceAll(s, "\n", " ")
+	return "`" + s + "`"
+}
+
+// getWriteAccessToken gets an access token with issue write permission to the
+// repo which contains the issue.
+func (w *IssueWriter) getWriteAccessToken(ctx context.Context, repoName string) (string, error) {
+	tr := &githubauth.TokenRequest{
+		Repositories: []string{repoName},
+		Permissions: map[string]string{
+			"issues": "write",
+		},
+	}
+
+	resp, err := w.githubInstallation.AccessToken(ctx, tr)
+	if err != nil {
+		return "", fmt.Errorf("failed to get access token: %w", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,320 @@
+// Copyright 2026 The Authors (see AUTHORS file)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v55/github"
+
+	"github.com/abcxyz/pkg/testutil"
+)
+
+// testBotLogin is the login of the bot user of the test GitHub App.
+const testBotLogin = "jvs-test[bot]"
+
+// testWriteBackServer is a fake GitHub server recording the write requests
+// made against test issue 1. When issue is set, it is served with the labels
+// and updated on each write like GitHub does.
+type testWriteBackServer struct {
+	mu       sync.Mutex
+	issue    *github.Issue
+	comments []*github.IssueComment
+	labels   []*github.Label
+	requests []string
+}
+
+// touch updates the last update time of the issue, if any.
+func (s *testWriteBackServer) touch() {
+	if s.issue != nil {
+		s.issue.UpdatedAt = &github.Timestamp{Time: s.issue.GetUpdatedAt().Add(time.Minute)}
+	}
+}
+
+func (s *testWriteBackServer) handle(tb testing.TB) func(w http.ResponseWriter, r *http.Request) {
+	tb.Helper()
+
+	issuePath := fmt.Sprintf("%s/%s/%s/issues/%v", issueRESTAPIPathPrefix, testIssueOwner, testIssueRepoName, testExistIssueNumber)
+	commentPathPrefix := fmt.Sprintf("%s/%s/%s/issues/comments/", issueRESTAPIPathPrefix, testIssueOwner, testIssueRepoName)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		var raw json.RawMessage
+		if r.Body != nil {
+			_ = json.NewDecoder(r.Body).Decode(&raw)
+		}
+		var body github.IssueComment
+		_ = json.Unmarshal(raw, &body)
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/app":
+			fmt.Fprint(w, `{"slug": "jvs-test"}`)
+		case r.Method == http.MethodGet && r.URL.Path == issuePath && s.issue != nil:
+			s.issue.Labels = s.labels
+			if err := json.NewEncoder(w).Encode(s.issue); err != nil {
+				tb.Errorf("failed to write issue: %v", err)
+			}
+		case r.Method == http.MethodGet && r.URL.Path == issuePath+"/comments":
+			if err := json.NewEncoder(w).Encode(s.comments); err != nil {
+				tb.Errorf("failed to write comments: %v", err)
+			}
+		case r.Method == http.MethodPost && r.URL.Path == issuePath+"/comments":
+			s.requests = append(s.requests, "create "+body.GetBody())
+			s.comments = append(s.comments, &github.IssueComment{
+				ID:   github.Int64(1),
+				Body: body.Body,
+				User: &github.User{Login: github.String(testBotLogin)},
+			})
+			s.touch()
+			fmt.Fprintf(w, `{"id": 1}`)
+		case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, commentPathPrefix):
+			s.requests = append(s.requests, "edit "+strings.TrimPrefix(r.URL.Path, commentPathPrefix)+" "+body.GetBody())
+			s.touch()
+			fmt.Fprintf(w, `{"id": 1}`)
+		case r.Method == http.MethodGet && r.URL.Path == issuePath+"/labels":
+			if err := json.NewEncoder(w).Encode(s.labels); err != nil {
+				tb.Errorf("failed to write labels: %v", err)
+			}
+		case r.Method == http.MethodPost && r.URL.Path == issuePath+"/labels":
+			s.requests = append(s.requests, "label "+string(raw))
+			var names []string
+			_ = json.Unmarshal(raw, &names)
+			for _, name := range names {
+				s.labels = append(s.labels, &github.Label{Name: github.String(name)})
+			}
+			s.touch()
+			fmt.Fprintf(w, `[]`)
+		default:
+			http.Error(w, "injected server error", http.StatusInternalServerError)
+		}
+	}
+}
+
+func TestIssueWriter_WriteBack(t *testing.T) {
+	t.Parallel()
+
+	entry := &auditEntry{
+		Time:      time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+		Requester: "user@example.com",
+		Audience:  "prod|db",
+	}
+	wantRow := "| 2023-01-02T03:04:05Z | `user@example.com` | `prod\\|db` |"
+	testBot := &github.User{Login: github.String(testBotLogin)}
+
+	cases := []struct {
+		name         string
+		cfg          *PluginConfig
+		comments     []*github.IssueComment
+		labels       []*github.Label
+		wantRequests []string
+		wantErr      string
+	}{
+		{
+			name: "create_comment",
+			cfg:  &PluginConfig{GitHubAuditComment: true},
+			comments: []*github.IssueComment{
+				{ID: github.Int64(5), Body: github.String("unrelated")},
+			},
+			wantRequests: []string{
+				"create " + auditCommentHeader + wantRow + "\n",
+			},
+		},
+		{
+			name: "update_comment",
+			cfg:  &PluginConfig{GitHubAuditComment: true},
+			comments: []*github.IssueComment{
+				{ID: github.Int64(5), Body: github.String("unrelated")},
+				{ID: github.Int64(7), User: testBot, Body: github.String(auditCommentHeader + "| old | a | b |\n")},
+			},
+			wantRequests: []string{
+				"edit 7 " + auditCommentHeader + "| old | a | b |\n" + wantRow + "\n",
+			},
+		},
+		{
+			name: "ignore_comment_by_other_user",
+			cfg:  &PluginConfig{GitHubAuditComment: true},
+			comments: []*github.IssueComment{
+				{ID: github.Int64(6), User: &github.User{Login: github.String("mallory")}, Body: github.String(auditCommentHeader + "| fake | a | b |\n")},
+				{ID: github.Int64(7), User: testBot, Body: github.String(auditCommentHeader + "| old | a | b |\n")},
+			},
+			wantRequests: []string{
+				"edit 7 " + auditCommentHeader + "| old | a | b |\n" + wantRow + "\n",
+			},
+		},
+		{
+			name: "create_comment_ignoring_other_user",
+			cfg:  &PluginConfig{GitHubAuditComment: true},
+			comments: []*github.IssueComment{
+				{ID: github.Int64(6), User: &github.User{Login: github.String("mallory")}, Body: github.String(auditCommentHeader + "| fake | a | b |\n")},
+			},
+			wantRequests: []string{
+				"create " + auditCommentHeader + wantRow + "\n",
+			},
+		},
+		{
+			name: "update_comment_previous_header",
+			cfg:  &PluginConfig{GitHubAuditComment: true},
+			comments: []*github.IssueComment{
+				{ID: github.Int64(7), User: testBot, Body: github.String(auditCommentMarker + "\n" +
+					"This issue has been used as a justification for access through JVS.\n\n" +
+					"| Time (UTC) | Requester | Audience |\n" +
+					"| --- | --- | --- |\n" +
+					"| old | a | b |\n")},
+			},
+			wantRequests: []string{
+				"edit 7 " + auditCommentHeader + "| old | a | b |\n" + wantRow + "\n",
+			},
+		},
+		{
+			name: "add_label",
+			cfg:  &PluginConfig{GitHubUsedLabel: "jvs:used"},
+			labels: []*github.Label{
+				{Name: github.String("incident")},
+			},
+			wantRequests: []string{
+				`label ["jvs:used"]`,
+			},
+		},
+		{
+			name: "label_already_present",
+			cfg:  &PluginConfig{GitHubUsedLabel: "jvs:used"},
+			labels: []*github.Label{
+				{Name: github.String("JVS:used")},
+			},
+		},
+		{
+			name: "label_dry_run",
+			cfg:  &PluginConfig{GitHubUsedLabel: "jvs:used", GitHubUsedLabelDryRun: true},
+		},
+		{
+			name: "comment_and_label",
+			cfg:  &PluginConfig{GitHubAuditComment: true, GitHubUsedLabel: "jvs:used"},
+			wantRequests: []string{
+				"create " + auditCommentHeader + wantRow + "\n",
+				`label ["jvs:used"]`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := &testWriteBackServer{comments: tc.comments, labels: tc.labels}
+			hc := newTestServer(t, srv.handle(t))
+			installation := testGitHubInstallation(t, http.StatusCreated)
+
+			w := NewIssueWriter(github.NewClient(hc), installation, tc.cfg)
+			err := w.WriteBack(t.Context(), &pluginGitHubIssue{
+				Owner:       testIssueOwner,
+				RepoName:    testIssueRepoName,
+				IssueNumber: testExistIssueNumber,
+			}, entry)
+			if diff := testutil.DiffErrString(err, tc.wantErr); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tc.wantRequests, srv.requests); diff != "" {
+				t.Errorf("unexpected requests (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewIssueWriter_Disabled(t *testing.T) {
+	t.Parallel()
+
+	if w := NewIssueWriter(nil, nil, &PluginConfig{}); w != nil {
+		t.Errorf("NewIssueWriter() = %v, want nil", w)
+	}
+}
+
+func TestEscapeTableCell(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{
+			name: "empty",
+			want: "unknown",
+		},
+		{
+			name: "plain",
+			s:    "user@example.com",
+			want: "`user@example.com`",
+		},
+		{
+			name: "mention",
+			s:    "@my-org/sre please approve",
+			want: "`@my-org/sre please approve`",
+		},
+		{
+			name: "link",
+			s:    "[prod](https://evil.example.com) https://evil.example.com",
+			want: "`[prod](https://evil.example.com) https://evil.example.com`",
+		},
+		{
+			name: "code_span_breakout",
+			s:    "` @octocat `",
+			want: "`' @octocat '`",
+		},
+		{
+			name: "table_breakout",
+			s:    "prod|db\r\n| fake | row |",
+			want: "`prod\\|db  \\| fake \\| row \\|`",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := escapeTableCell(tc.s); got != tc.want {
+				t.Errorf("escapeTableCell(%q) = %q, want %q", tc.s, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRenderAuditComment_TrimsRows(t *testing.T) {
+	t.Parallel()
+
+	rows := make([]string, 0, maxAuditCommentRows)
+	for i := 0; i < maxAuditCommentRows; i++ {
+		rows = append(rows, fmt.Sprintf("| row-%d | a | b |", i))
+	}
+
+	got := parseAuditCommentRows(renderAuditComment(rows, &auditEntry{Requester: "new"}))
+	if len(got) != maxAuditCommentRows {
+		t.Fatalf("got %d rows, want %d", len(got), maxAuditCommentRows)
+	}
+	if !strings.HasPrefix(got[0], "| row-1 ") {
+		t.Errorf("expected oldest row to be dropped, got first row %q", got[0])
+	}
+	if !strings.Contains(got[len(got)-1], "| `new` |") {
+		t.Errorf("expected newest row last, got %q", got[len(got)-1])
+	}
+}